@@ -0,0 +1,107 @@
+// Package annotations is the runtime registry of the annotations of types, filled by the init functions generated by
+// bud for the types marked with @Registry.
+package annotations
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Annotation is an annotation of a type parsed by bud, like @Rest(path="/users").
+type Annotation struct {
+	Name   string
+	Params []Param
+	// Extends are the items in the braces of the annotation, like the items of @ENUM.
+	Extends []Extend
+}
+
+// Param is a param of an annotation, Value is nil for a param without value. A list value is a []any, a map value is a
+// map[string]any and a nested annotation is an *Annotation.
+type Param struct {
+	Key   string
+	Value any
+}
+
+// Extend is an item in the braces of an annotation.
+type Extend struct {
+	Name   string
+	Values []any
+	Value  any
+}
+
+// Param returns the value of the param key, the keys are case-insensitive. ok is false if the param is not given.
+func (a *Annotation) Param(key string) (value any, ok bool) {
+	for _, p := range a.Params {
+		if strings.EqualFold(p.Key, key) {
+			return p.Value, true
+		}
+	}
+	return nil, false
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[reflect.Type][]*Annotation{}
+)
+
+// Register registers the annotations of T, it is called by the generated init functions.
+func Register[T any](annotations ...*Annotation) {
+	RegisterType(reflect.TypeOf((*T)(nil)).Elem(), annotations...)
+}
+
+// RegisterType registers the annotations of t, registering a type twice replaces its annotations.
+func RegisterType(t reflect.Type, annotations ...*Annotation) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registry[t] = annotations
+}
+
+// Of returns the annotations of T, or nil if none is registered.
+func Of[T any]() []*Annotation {
+	return OfType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// OfType returns the annotations of t, or nil if none is registered.
+func OfType(t reflect.Type) []*Annotation {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return registry[t]
+}
+
+// Find returns the annotation name of T, the names are case-insensitive. It returns nil if T has no such annotation.
+func Find[T any](name string) *Annotation {
+	return FindType(reflect.TypeOf((*T)(nil)).Elem(), name)
+}
+
+// FindType returns the annotation name of t, the names are case-insensitive. It returns nil if t has no such annotation.
+func FindType(t reflect.Type, name string) *Annotation {
+	for _, a := range OfType(t) {
+		if strings.EqualFold(a.Name, name) {
+			return a
+		}
+	}
+	return nil
+}
+
+// Types returns the registered types having the annotation name sorted by their string, like all types of @Singleton.
+func Types(name string) []reflect.Type {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	var result []reflect.Type
+	for t, annotations := range registry {
+		for _, a := range annotations {
+			if strings.EqualFold(a.Name, name) {
+				result = append(result, t)
+				break
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].String() < result[j].String() })
+	return result
+}
@@ -0,0 +1,33 @@
+package annotations
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type userService struct{}
+
+type orderService struct{}
+
+func TestRegister(t *testing.T) {
+	Register[userService](
+		&Annotation{Name: "Singleton"},
+		&Annotation{Name: "Rest", Params: []Param{{Key: "path", Value: "/users"}, {Key: "methods", Value: []any{"GET", "POST"}}}},
+	)
+	Register[orderService](&Annotation{Name: "singleton"})
+
+	assert.Len(t, Of[userService](), 2)
+	assert.Nil(t, Of[int]())
+
+	rest := Find[userService]("rest")
+	assert.NotNil(t, rest)
+	path, ok := rest.Param("Path")
+	assert.True(t, ok)
+	assert.Equal(t, "/users", path)
+	_, ok = rest.Param("unknown")
+	assert.False(t, ok)
+	assert.Nil(t, Find[orderService]("Rest"))
+
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(orderService{}), reflect.TypeOf(userService{})}, Types("Singleton"))
+}
@@ -0,0 +1,303 @@
+package bud
+
+import (
+	"encoding/json"
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/peace0phmind/bud/bud/ast"
+	goast "go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"strings"
+)
+
+// Position is a line and column in a source file.
+type Position struct {
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+func tokenPosition(pos token.Position) Position {
+	return Position{Filename: pos.Filename, Line: pos.Line, Column: pos.Column}
+}
+
+func lexerPosition(pos lexer.Position) Position {
+	return Position{Filename: pos.Filename, Line: pos.Line, Column: pos.Column}
+}
+
+// AnnotationTarget is the declaration an annotation comment is attached to.
+type AnnotationTarget struct {
+	// Kind is "type", "func", "method", "field", "const", "var" or "file" for the //go:generate comment of a file.
+	Kind string `json:"kind"`
+	Name string `json:"name,omitempty"`
+	// Type is the underlying type expression of a type declaration, or the type of a field, const or var.
+	Type string `json:"type,omitempty"`
+	// Receiver is the receiver type of a method, like "*Foo".
+	Receiver string `json:"receiver,omitempty"`
+	// Parent is the type declaring a struct field or an interface method.
+	Parent string   `json:"parent,omitempty"`
+	Pos    Position `json:"pos"`
+}
+
+// AnnotationParam is a param of an annotation, Value is null for a param without value like "marshal".
+// A list value is an array, a map value is an object and a nested annotation is an Annotation object.
+type AnnotationParam struct {
+	Key      string   `json:"key"`
+	Value    any      `json:"value"`
+	Comments []string `json:"comments,omitempty"`
+	Comment  string   `json:"comment,omitempty"`
+	Pos      Position `json:"pos"`
+}
+
+// AnnotationExtend is an item in the braces of an annotation, like an enum item.
+type AnnotationExtend struct {
+	Name   string `json:"name"`
+	Values []any  `json:"values,omitempty"`
+	Value  any    `json:"value,omitempty"`
+	// Transitions are the names of the items following "->".
+	Transitions []string `json:"transitions,omitempty"`
+	Comments    []string `json:"comments,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+	Pos         Position `json:"pos"`
+}
+
+// Annotation is a parsed annotation like @ENUM or @EnumConfig.
+type Annotation struct {
+	Name     string              `json:"name"`
+	Params   []*AnnotationParam  `json:"params,omitempty"`
+	Extends  []*AnnotationExtend `json:"extends,omitempty"`
+	Comments []string            `json:"comments,omitempty"`
+	Comment  string              `json:"comment,omitempty"`
+	Pos      Position            `json:"pos"`
+}
+
+// AnnotationGroup is the annotations of one comment group and the declaration they are attached to.
+// Target is nil if the comment group is not attached to a declaration.
+type AnnotationGroup struct {
+	Package     string            `json:"package"`
+	Target      *AnnotationTarget `json:"target,omitempty"`
+	Annotations []*Annotation     `json:"annotations"`
+	Pos         Position          `json:"pos"`
+}
+
+// DumpAnnotations writes the annotation groups of all packages matched by patterns to w as a JSON array.
+// Comment groups which can not be parsed are reported as warnings and left out, they may be plain comments holding
+// an "@" as well.
+func DumpAnnotations(patterns []string, w io.Writer) (diagnostics ast.Diagnostics) {
+	fileSet := token.NewFileSet()
+
+	pkgs, err := LoadPackages(fileSet, patterns...)
+	diagnostics.Append(err, token.Position{})
+
+	groups := []*AnnotationGroup{}
+	for _, pkg := range pkgs {
+		for _, fileNode := range pkg.Files {
+			fileGroups, fd := CollectAnnotations(fileNode, fileSet)
+			diagnostics.Add(fd...)
+			groups = append(groups, fileGroups...)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	diagnostics.Append(encoder.Encode(groups), token.Position{})
+	return
+}
+
+// CollectAnnotations parses every comment group of the file holding an annotation, in source order.
+// A comment group which can not be parsed is reported as a warning.
+func CollectAnnotations(fileNode *goast.File, fileSet *token.FileSet) (groups []*AnnotationGroup, diagnostics ast.Diagnostics) {
+	targets := annotationTargets(fileNode, fileSet)
+
+	for _, cg := range fileNode.Comments {
+		if !strings.Contains(cg.Text(), "@") {
+			continue
+		}
+
+		ag, err := ast.ParseCommentGroup(fileSet, cg)
+		if err != nil {
+			diagnostics.Add(ast.Warnings(err, fileSet.Position(cg.Pos()))...)
+			continue
+		}
+		if len(ag.Annotations) == 0 {
+			continue
+		}
+
+		group := &AnnotationGroup{
+			Package: fileNode.Name.Name,
+			Target:  targets[cg],
+			Pos:     tokenPosition(fileSet.Position(cg.Pos())),
+		}
+		for _, a := range ag.Annotations {
+			group.Annotations = append(group.Annotations, newAnnotation(a))
+		}
+		groups = append(groups, group)
+	}
+
+	return
+}
+
+// annotationTargets maps the doc and line comment groups of the declarations of the file to the declarations.
+// The comment groups are found the same way as ast.InspectMapper does.
+func annotationTargets(fileNode *goast.File, fileSet *token.FileSet) map[*goast.CommentGroup]*AnnotationTarget {
+	targets := map[*goast.CommentGroup]*AnnotationTarget{}
+
+	// the annotations of the file are in the comment group ending with //go:generate, like the global @EnumConfig
+	for _, cg := range fileNode.Comments {
+		if strings.HasPrefix(cg.List[len(cg.List)-1].Text, "//go:generate") {
+			targets[cg] = &AnnotationTarget{Kind: "file", Pos: tokenPosition(fileSet.Position(fileNode.Package))}
+		}
+	}
+
+	goast.Inspect(fileNode, func(n goast.Node) bool {
+		switch decl := n.(type) {
+		case *goast.TypeSpec:
+			target := &AnnotationTarget{
+				Kind: "type",
+				Name: decl.Name.Name,
+				Type: types.ExprString(decl.Type),
+				Pos:  tokenPosition(fileSet.Position(decl.Pos())),
+			}
+			addTarget(targets, target,
+				decl.Doc,
+				decl.Comment,
+				ast.FindDocLocationCommentGroup(fileNode, fileSet, decl.Pos()),
+				ast.FindCommentLocationCommentGroup(fileNode, fileSet, decl.Pos()),
+			)
+		case *goast.FuncDecl:
+			target := &AnnotationTarget{
+				Kind: "func",
+				Name: decl.Name.Name,
+				Pos:  tokenPosition(fileSet.Position(decl.Pos())),
+			}
+			if decl.Recv != nil && len(decl.Recv.List) == 1 {
+				target.Kind = "method"
+				target.Receiver = types.ExprString(decl.Recv.List[0].Type)
+			}
+			addTarget(targets, target, decl.Doc, ast.FindDocLocationCommentGroup(fileNode, fileSet, decl.Pos()))
+		}
+
+		return true
+	})
+
+	ast.InspectMapper[ast.FieldDecl, any](fileNode, fileSet, func(fd *ast.FieldDecl) *any {
+		target := &AnnotationTarget{
+			Kind: "field",
+			Name: strings.Join(fd.Names(), ", "),
+			Type: types.ExprString(fd.Field.Type),
+			Pos:  tokenPosition(fileSet.Position(fd.Field.Pos())),
+		}
+		if fd.Method {
+			target.Kind, target.Type = "method", ""
+		}
+		if fd.TypeSpec != nil {
+			target.Parent = fd.TypeSpec.Name.Name
+		}
+		addTarget(targets, target, fd.Field.Doc, fd.Field.Comment)
+		return nil
+	})
+
+	ast.InspectMapper[ast.ValueDecl, any](fileNode, fileSet, func(vd *ast.ValueDecl) *any {
+		names := make([]string, len(vd.Spec.Names))
+		for i, name := range vd.Spec.Names {
+			names[i] = name.Name
+		}
+
+		target := &AnnotationTarget{
+			Kind: vd.GenDecl.Tok.String(),
+			Name: strings.Join(names, ", "),
+			Pos:  tokenPosition(fileSet.Position(vd.Spec.Pos())),
+		}
+		if vd.Spec.Type != nil {
+			target.Type = types.ExprString(vd.Spec.Type)
+		}
+		addTarget(targets, target, vd.Spec.Doc, vd.Spec.Comment)
+		return nil
+	})
+
+	return targets
+}
+
+// addTarget maps the comment groups to target, unless they are mapped already.
+func addTarget(targets map[*goast.CommentGroup]*AnnotationTarget, target *AnnotationTarget, groups ...*goast.CommentGroup) {
+	for _, cg := range groups {
+		if cg != nil && targets[cg] == nil {
+			targets[cg] = target
+		}
+	}
+}
+
+func newAnnotation(a *ast.Annotation) *Annotation {
+	result := &Annotation{
+		Name:     a.Name.Text,
+		Comments: commentsText(a.Comments),
+		Comment:  ast.GetCommentText(a.Comment),
+		Pos:      lexerPosition(a.Name.Pos),
+	}
+
+	if a.Params != nil {
+		for _, p := range a.Params.List {
+			result.Params = append(result.Params, &AnnotationParam{
+				Key:      p.Key.Text,
+				Value:    value(p.Value),
+				Comments: commentsText(p.Comments),
+				Comment:  ast.GetCommentText(p.Comment),
+				Pos:      lexerPosition(p.Key.Pos),
+			})
+		}
+	}
+
+	if a.Extends != nil {
+		for _, ex := range a.Extends.List {
+			extend := &AnnotationExtend{
+				Name:     ex.Name.Text,
+				Value:    value(ex.Value),
+				Comments: commentsText(ex.Comments),
+				Comment:  ast.GetCommentText(ex.Comment),
+				Pos:      lexerPosition(ex.Name.Pos),
+			}
+			for _, v := range ex.Values {
+				extend.Values = append(extend.Values, value(v))
+			}
+			for _, name := range ex.Transitions {
+				extend.Transitions = append(extend.Transitions, name.Text)
+			}
+			result.Extends = append(result.Extends, extend)
+		}
+	}
+
+	return result
+}
+
+// value returns the JSON value of v, a nested annotation is an object like an Annotation.
+func value(v ast.Value) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case ast.List:
+		values := make([]any, len(v.V))
+		for i, item := range v.V {
+			values[i] = value(item)
+		}
+		return values
+	case ast.Map:
+		values := make(map[string]any, len(v.Entries))
+		for _, e := range v.Entries {
+			values[e.Key] = value(e.Value)
+		}
+		return values
+	case ast.NestedAnnotation:
+		return newAnnotation(v.Annotation())
+	default:
+		return v.Value()
+	}
+}
+
+func commentsText(comments []*ast.Comment) []string {
+	var result []string
+	for _, c := range comments {
+		result = append(result, c.Text)
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package bud
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDumpAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	src := `package color
+
+// @EnumConfig(marshal)
+//go:generate bud
+
+// Color doc
+// @ENUM(code int){
+// red(1) // red color
+// green(2)
+// }
+type Color int
+
+// @Singleton(names=[a, b], opts={lazy: true}, inner=@Inner(size=2))
+func (c *Color) Get() {}
+
+func Plain() {}
+
+type User struct {
+	// @Column(name=id)
+	ID int
+}
+
+type Repo interface {
+	Find(id int) User // @Inject
+}
+
+// @Version
+const Version = "1"
+`
+	file := filepath.Join(dir, "color.go")
+	assert.NoError(t, os.WriteFile(file, []byte(src), 0o644))
+
+	w := &bytes.Buffer{}
+	diagnostics := DumpAnnotations([]string{dir}, w)
+	assert.False(t, diagnostics.HasErrors(), diagnostics.Error())
+
+	var groups []*AnnotationGroup
+	assert.NoError(t, json.Unmarshal(w.Bytes(), &groups))
+	assert.Len(t, groups, 6)
+
+	assert.Equal(t, &AnnotationTarget{Kind: "file", Pos: Position{Filename: file, Line: 1, Column: 1}}, groups[0].Target)
+	assert.Equal(t, "EnumConfig", groups[0].Annotations[0].Name)
+	assert.Equal(t, "marshal", groups[0].Annotations[0].Params[0].Key)
+	assert.Nil(t, groups[0].Annotations[0].Params[0].Value)
+
+	assert.Equal(t, &AnnotationTarget{Kind: "type", Name: "Color", Type: "int", Pos: Position{Filename: file, Line: 11, Column: 6}}, groups[1].Target)
+	enum := groups[1].Annotations[0]
+	assert.Equal(t, "ENUM", enum.Name)
+	assert.Equal(t, Position{Filename: file, Line: 7, Column: 5}, enum.Pos)
+	assert.Equal(t, "int", enum.Params[0].Value)
+	assert.Equal(t, "red", enum.Extends[0].Name)
+	assert.Equal(t, []any{float64(1)}, enum.Extends[0].Values)
+	assert.Equal(t, "// red color", enum.Extends[0].Comment)
+	assert.Equal(t, Position{Filename: file, Line: 9, Column: 4}, enum.Extends[1].Pos)
+
+	assert.Equal(t, &AnnotationTarget{Kind: "method", Name: "Get", Receiver: "*Color", Pos: Position{Filename: file, Line: 14, Column: 1}}, groups[2].Target)
+	singleton := groups[2].Annotations[0]
+	assert.Equal(t, "Singleton", singleton.Name)
+	assert.Equal(t, []any{"a", "b"}, singleton.Params[0].Value)
+	assert.Equal(t, map[string]any{"lazy": true}, singleton.Params[1].Value)
+	assert.Equal(t, "Inner", singleton.Params[2].Value.(map[string]any)["name"])
+
+	assert.Equal(t, &AnnotationTarget{Kind: "field", Name: "ID", Type: "int", Parent: "User", Pos: Position{Filename: file, Line: 20, Column: 2}}, groups[3].Target)
+	assert.Equal(t, &AnnotationTarget{Kind: "method", Name: "Find", Parent: "Repo", Pos: Position{Filename: file, Line: 24, Column: 2}}, groups[4].Target)
+	assert.Equal(t, &AnnotationTarget{Kind: "const", Name: "Version", Pos: Position{Filename: file, Line: 28, Column: 7}}, groups[5].Target)
+}
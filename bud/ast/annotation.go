@@ -1,19 +1,24 @@
 package ast
 
 import (
+	"fmt"
 	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/peace0phmind/bud/factory"
 	"github.com/peace0phmind/bud/stream"
-	"github.com/peace0phmind/bud/structure"
+	goast "go/ast"
+	"go/token"
 	"reflect"
+	"sort"
 	"strings"
 	"text/scanner"
 )
 
 type Key struct {
 	Pos  lexer.Position
-	Text string `@Ident "="?`
+	Text string `@(Ident ("." Ident)*)`
+	// Assign reports whether the key is followed by "=", a value may also be given after a space like @ENUM(code int).
+	Assign bool `@"="?`
 }
 
 type Name struct {
@@ -73,20 +78,27 @@ type AnnotationExtend struct {
 	Comments []*Comment `@@*`
 	Name     Name       `@@`
 	Values   []Value    `("(" @@* ")")?`
-	Value    Value      `("=" @@)? ","?`
-	Comment  *Comment   `@@?`
+	Value    Value      `("=" @@)?`
+	// Transitions are the items following "->", like pending -> inWork, rejected. A name after a comma is the next
+	// item if it has values, a value or transitions, brackets like a -> [b, c], d end the list explicitly.
+	Transitions []*Name  `("-" ">" ("[" (@@ ","?)* "]" | @@ ((?! "," Ident ("(" | "=" | "-")) "," @@)*))? ","?`
+	Comment     *Comment `@@?`
 }
 
+// Value is an annotation value. The Tokens of a parsed scalar value are its source tokens, which keep the literal
+// of the value, like the base of a number or the quotes of a string.
 type Value interface{ Value() any }
 
 type Float struct {
-	V float64 `@Float ","? `
+	V      float64 `@Float ","? `
+	Tokens []lexer.Token
 }
 
 func (f Float) Value() any { return f.V }
 
 type Int struct {
-	V int `@(("-" | "+")? Int) ","? `
+	V      int `@(("-" | "+")? Int) ","? `
+	Tokens []lexer.Token
 }
 
 func (i Int) Value() any {
@@ -94,7 +106,8 @@ func (i Int) Value() any {
 }
 
 type Uint struct {
-	V uint `@Int ","? `
+	V      uint `@Int ","? `
+	Tokens []lexer.Token
 }
 
 func (u Uint) Value() any {
@@ -102,7 +115,8 @@ func (u Uint) Value() any {
 }
 
 type String struct {
-	V string `@(String | Ident) ","? `
+	V      string `@(String | Ident ("." Ident)*) ","? `
+	Tokens []lexer.Token
 }
 
 func (s String) Value() any {
@@ -124,6 +138,63 @@ func (b Bool) Value() any {
 	return bool(b.V)
 }
 
+type List struct {
+	V []Value `"[" @@* "]" ","? `
+}
+
+func (l List) Value() any {
+	values := make([]any, len(l.V))
+	for i, v := range l.V {
+		values[i] = v.Value()
+	}
+	return values
+}
+
+type MapEntry struct {
+	Pos   lexer.Position
+	Key   string `@(String | Ident) ":"`
+	Value Value  `@@`
+}
+
+type Map struct {
+	Entries []*MapEntry `"{" @@* "}" ","? `
+}
+
+func (m Map) Value() any {
+	values := make(map[string]any, len(m.Entries))
+	for _, e := range m.Entries {
+		if e.Value != nil {
+			values[e.Key] = e.Value.Value()
+		} else {
+			values[e.Key] = nil
+		}
+	}
+	return values
+}
+
+// Params returns the entries of the map as annotation params, so a map can be decoded like a nested annotation.
+func (m Map) Params() []*AnnotationParam {
+	params := make([]*AnnotationParam, len(m.Entries))
+	for i, e := range m.Entries {
+		params[i] = &AnnotationParam{Pos: e.Pos, Key: Key{Pos: e.Pos, Text: e.Key}, Value: e.Value}
+	}
+	return params
+}
+
+// NestedAnnotation is an annotation used as a param value, like @Label(en="Red").
+type NestedAnnotation struct {
+	Name   Name    `"@" @@`
+	Params *Params `@@? ","? `
+}
+
+func (n NestedAnnotation) Value() any {
+	return n.Annotation()
+}
+
+func (n NestedAnnotation) Annotation() *Annotation {
+	return &Annotation{Name: n.Name, Params: n.Params}
+}
+
 //type Unknown struct {
 //	V string `@Ident ","? `
 //}
@@ -134,7 +205,7 @@ var annotationParser = participle.MustBuild[AnnotationGroup](
 	participle.Lexer(lexer.NewTextScannerLexer(func(s *scanner.Scanner) {
 		s.Mode &^= scanner.SkipComments
 	})),
-	participle.Union[Value](Bool{}, Float{}, Int{}, Uint{}, String{}),
+	participle.Union[Value](Bool{}, Float{}, Int{}, Uint{}, String{}, List{}, Map{}, NestedAnnotation{}),
 	participle.Unquote("String"),
 )
 
@@ -179,56 +250,148 @@ func fixComments(annotationGroup *AnnotationGroup, err error) (*AnnotationGroup,
 	return annotationGroup, err
 }
 
-var defaultBoolValue = any(Bool{V: true}).(Value)
-
+// AnnotationParamsTo sets the params of a to the fields of val, or a new T if val is nil. A param is matched to a
+// field by the name and aliases declared by its AnnotationTag. If a schema is registered for a, a is validated first.
+// Lists are decoded into slices, maps into maps or structs, and nested annotations into structs.
 func AnnotationParamsTo[T any](val *T, a *Annotation) (t *T, err error) {
 	t = val
 	if t == nil {
 		t = factory.New[T]()
 	}
 
+	if schema := GetSchema(a.Name.Text); schema != nil {
+		if err = schema.Validate(a); err != nil {
+			return t, err
+		}
+	}
+
 	if a.Params != nil {
-		err = structure.WalkField(t, func(fieldValue reflect.Value, structField reflect.StructField, rootValues []reflect.Value) error {
-			switch fieldValue.Kind() {
-			case reflect.Ptr, reflect.Struct:
-				return nil
-			default:
-			}
+		err = decodeParams(reflect.ValueOf(t).Elem(), "@"+a.Name.Text, a.Params.List)
+	}
 
-			var ap *AnnotationParam = nil
+	return
+}
 
-			for _, p := range a.Params.List {
-				if strings.EqualFold(structField.Name, p.Key.Text) {
-					ap = p
-					break
-				}
+// NewValue returns the annotation param Value of a bool, number, string, or a list or map of them.
+func NewValue(v any) (Value, error) {
+	switch value := v.(type) {
+	case []any:
+		list := List{}
+		for _, item := range value {
+			itemValue, err := NewValue(item)
+			if err != nil {
+				return nil, err
 			}
+			list.V = append(list.V, itemValue)
+		}
+		return list, nil
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
 
-			if ap == nil {
-				return nil
+		m := Map{}
+		for _, key := range keys {
+			entryValue, err := NewValue(value[key])
+			if err != nil {
+				return nil, err
 			}
+			m.Entries = append(m.Entries, &MapEntry{Key: key, Value: entryValue})
+		}
+		return m, nil
+	case bool:
+		return Bool{V: Boolean(value)}, nil
+	case int:
+		return Int{V: value}, nil
+	case uint:
+		return Uint{V: value}, nil
+	case float64:
+		return Float{V: value}, nil
+	case string:
+		return String{V: value}, nil
+	default:
+		return nil, fmt.Errorf("unsupported annotation value %v of type %T", v, v)
+	}
+}
 
-			if fieldValue.Kind() == reflect.Bool && ap.Value == nil {
-				ap.Value = defaultBoolValue
-			}
+func ParseAnnotation(fileName string, text string) (*AnnotationGroup, error) {
+	return fixComments(annotationParser.ParseString(fileName, text))
+}
 
-			if ap.Value != nil {
-				value := structure.MustConvertToType(ap.Value.Value(), fieldValue.Type())
-				if structure.SetFieldBySetMethod(fieldValue, value, structField, rootValues[len(rootValues)-1]) {
-					return nil
-				}
-				return structure.SetField(fieldValue, value)
-			}
+// ParseCommentGroup parses the annotations of a go comment group.
+// The positions recorded in the result are the line and column in the source file.
+func ParseCommentGroup(fileSet *token.FileSet, cg *goast.CommentGroup) (*AnnotationGroup, error) {
+	fileName, text := commentGroupText(fileSet, cg)
+	return ParseAnnotation(fileName, text)
+}
 
-			return nil
-		})
+// commentGroupText returns the text of the comment group with all comment markers and directives replaced by spaces.
+// The text is padded with newlines and spaces in front, so that every character keeps its source line and column.
+func commentGroupText(fileSet *token.FileSet, cg *goast.CommentGroup) (string, string) {
+	if cg == nil || len(cg.List) == 0 {
+		return "", ""
 	}
 
-	return
+	fileName := fileSet.Position(cg.Pos()).Filename
+	buf := strings.Builder{}
+	line, column := 1, 1
+
+	for _, c := range cg.List {
+		pos := fileSet.Position(c.Pos())
+		for ; line < pos.Line; line++ {
+			buf.WriteByte('\n')
+			column = 1
+		}
+		for ; column < pos.Column; column++ {
+			buf.WriteByte(' ')
+		}
+
+		text := c.Text
+		switch {
+		case isDirective(text):
+			text = strings.Repeat(" ", len(text))
+		case strings.HasPrefix(text, "//"):
+			text = "  " + text[2:]
+		default:
+			text = "  " + text[2:len(text)-2] + "  "
+		}
+		buf.WriteString(text)
+
+		if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+			line += strings.Count(text, "\n")
+			column = len(text) - i
+		} else {
+			column += len(text)
+		}
+	}
+
+	return fileName, buf.String()
 }
 
-func ParseAnnotation(fileName string, text string) (*AnnotationGroup, error) {
-	return fixComments(annotationParser.ParseString(fileName, text))
+// isDirective reports whether c is a comment directive like //go:generate, the same as go/ast does.
+func isDirective(c string) bool {
+	if strings.HasPrefix(c, "//line ") || strings.HasPrefix(c, "//extern ") || strings.HasPrefix(c, "//export ") {
+		return true
+	}
+
+	colon := strings.Index(c, ":")
+	if !strings.HasPrefix(c, "//") || colon <= 2 || colon+1 >= len(c) {
+		return false
+	}
+
+	for i := 2; i <= colon+1; i++ {
+		if i == colon {
+			continue
+		}
+		b := c[i]
+		if !('a' <= b && b <= 'z' || '0' <= b && b <= '9') {
+			return false
+		}
+	}
+
+	return true
 }
 
 func GetCommentsText(comments []*Comment) string {
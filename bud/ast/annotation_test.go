@@ -137,19 +137,19 @@ sql comment2
 								Key: Key{Text: "disable"},
 							},
 							{
-								Key:   Key{Text: "string"},
+								Key:   Key{Text: "string", Assign: true},
 								Value: any(String{V: "str\"ing"}).(Value),
 							},
 							{
-								Key:   Key{Text: "int"},
+								Key:   Key{Text: "int", Assign: true},
 								Value: any(Int{V: 123}).(Value),
 							},
 							{
-								Key:   Key{Text: "double"},
+								Key:   Key{Text: "double", Assign: true},
 								Value: any(Float{V: 456.7}).(Value),
 							},
 							{
-								Key:   Key{Text: "bool"},
+								Key:   Key{Text: "bool", Assign: true},
 								Value: any(Bool{V: true}).(Value),
 							},
 						},
@@ -167,7 +167,7 @@ sql comment2
 								Value: any(String{V: "string"}).(Value),
 							},
 							{
-								Key:   Key{Text: "message"},
+								Key:   Key{Text: "message", Assign: true},
 								Value: any(String{V: "string"}).(Value),
 							},
 						},
@@ -199,19 +199,19 @@ sql comment2
 								Key: Key{Text: "disable"},
 							},
 							{
-								Key:   Key{Text: "string"},
+								Key:   Key{Text: "string", Assign: true},
 								Value: any(String{V: "str\"ing"}).(Value),
 							},
 							{
-								Key:   Key{Text: "int"},
+								Key:   Key{Text: "int", Assign: true},
 								Value: any(Int{V: 123}).(Value),
 							},
 							{
-								Key:   Key{Text: "double"},
+								Key:   Key{Text: "double", Assign: true},
 								Value: any(Float{V: 456.7}).(Value),
 							},
 							{
-								Key:   Key{Text: "bool"},
+								Key:   Key{Text: "bool", Assign: true},
 								Value: any(Bool{V: true}).(Value),
 							},
 						},
@@ -264,7 +264,7 @@ sql comment2
 								Value: any(String{V: "string"}).(Value),
 							},
 							{
-								Key:   Key{Text: "message"},
+								Key:   Key{Text: "message", Assign: true},
 								Value: any(String{V: "string"}).(Value),
 							},
 						},
@@ -343,7 +343,7 @@ tag comment 4
 									{Text: "// string comment 1"},
 									{Text: "// string comment 2"},
 								},
-								Key:   Key{Text: "string"},
+								Key:   Key{Text: "string", Assign: true},
 								Value: any(String{V: "str\"ing"}).(Value),
 							},
 							{
@@ -352,16 +352,16 @@ tag comment 4
        int comment 2
     */`},
 								},
-								Key:   Key{Text: "int"},
+								Key:   Key{Text: "int", Assign: true},
 								Value: any(Int{V: 123}).(Value),
 							},
 							{
-								Key:     Key{Text: "double"},
+								Key:     Key{Text: "double", Assign: true},
 								Value:   any(Float{V: 456.7}).(Value),
 								Comment: &Comment{Text: "// double inline comment"},
 							},
 							{
-								Key:   Key{Text: "bool"},
+								Key:   Key{Text: "bool", Assign: true},
 								Value: any(Bool{V: true}).(Value),
 							},
 						},
@@ -433,7 +433,7 @@ tag comment 4
 								Value: any(String{V: "string"}).(Value),
 							},
 							{
-								Key:   Key{Text: "message"},
+								Key:   Key{Text: "message", Assign: true},
 								Value: any(String{V: "string"}).(Value),
 							},
 						},
@@ -469,7 +469,7 @@ func ignorePosFields(path cmp.Path) bool {
 	for _, step := range path {
 		if t, ok := step.(cmp.StructField); ok {
 			// 如果步骤是结构体字段并且名字为"Pos"，则返回true以忽略
-			if t.Name() == "Pos" {
+			if t.Name() == "Pos" || t.Name() == "Tokens" {
 				return true
 			}
 		}
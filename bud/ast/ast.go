@@ -5,13 +5,15 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"strings"
 )
 
 func ParseFile(inputFile string) (*ast.File, *token.FileSet, error) {
 	fileSet := token.NewFileSet()
 	fileNode, err := parser.ParseFile(fileSet, inputFile, nil, parser.ParseComments)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generate: error parsing input file '%s': %s", inputFile, err)
+		return nil, nil, fmt.Errorf("generate: error parsing input file '%s': %w", inputFile, err)
 	}
 
 	return fileNode, fileSet, nil
@@ -42,9 +44,45 @@ func GetRecvType(fd *ast.FuncDecl) *ast.TypeSpec {
 	return nil
 }
 
+// FieldDecl is a struct field or an interface method, inspected by InspectMapper[FieldDecl, To].
+type FieldDecl struct {
+	Field *ast.Field
+	// TypeSpec is the type declaring the field, nil for a struct or interface type literal without type declaration.
+	TypeSpec *ast.TypeSpec
+	// Method reports whether the field is an interface method.
+	Method bool
+}
+
+// Names returns the names of the field, or the type name of an embedded field.
+func (fd *FieldDecl) Names() []string {
+	if len(fd.Field.Names) == 0 {
+		name := types.ExprString(fd.Field.Type)
+		return []string{name[strings.LastIndexAny(name, "*.")+1:]}
+	}
+
+	names := make([]string, len(fd.Field.Names))
+	for i, name := range fd.Field.Names {
+		names[i] = name.Name
+	}
+	return names
+}
+
+// ValueDecl is a const or var spec, inspected by InspectMapper[ValueDecl, To].
+type ValueDecl struct {
+	Spec *ast.ValueSpec
+	// GenDecl is the const or var declaration holding Spec, GenDecl.Tok tells which.
+	GenDecl *ast.GenDecl
+}
+
+// InspectMapper maps the nodes of the file of type From with mapper, the nodes mapped to nil are left out.
+// From can be a go/ast TypeSpec, FuncDecl, Comment or CommentGroup, or a FieldDecl or ValueDecl. The doc and line
+// comment groups of the declarations are resolved before they are mapped.
 func InspectMapper[From any, To any](fileNode *ast.File, fileSet *token.FileSet, mapper func(*From) *To) []*To {
 	result := []*To{}
 
+	// the type declarations of struct and interface types, for FieldDecl.TypeSpec
+	typeSpecs := map[ast.Expr]*ast.TypeSpec{}
+
 	ast.Inspect(fileNode, func(n ast.Node) bool {
 		switch decl := n.(type) {
 		case *ast.Comment, *ast.CommentGroup:
@@ -54,6 +92,8 @@ func InspectMapper[From any, To any](fileNode *ast.File, fileSet *token.FileSet,
 				}
 			}
 		case *ast.TypeSpec:
+			typeSpecs[decl.Type] = decl
+
 			if ts, ok := any(decl).(*From); ok {
 				if decl.Doc == nil {
 					decl.Doc = FindDocLocationCommentGroup(fileNode, fileSet, decl.Pos())
@@ -99,6 +139,46 @@ func InspectMapper[From any, To any](fileNode *ast.File, fileSet *token.FileSet,
 					result = append(result, t)
 				}
 			}
+		case *ast.StructType, *ast.InterfaceType:
+			if _, ok := any(&FieldDecl{}).(*From); !ok {
+				break
+			}
+
+			fields, method := fieldList(decl)
+			if fields == nil {
+				break
+			}
+			resolveFieldComments(fileNode, fileSet, fields)
+
+			for _, field := range fields.List {
+				fd := &FieldDecl{Field: field, TypeSpec: typeSpecs[decl.(ast.Expr)], Method: method(field)}
+				if t := mapper(any(fd).(*From)); t != nil {
+					result = append(result, t)
+				}
+			}
+		case *ast.GenDecl:
+			if _, ok := any(&ValueDecl{}).(*From); !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+				break
+			}
+
+			for i, spec := range decl.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if vs.Doc == nil {
+					if !decl.Lparen.IsValid() {
+						vs.Doc = decl.Doc
+					} else if cg := FindDocLocationCommentGroup(fileNode, fileSet, vs.Pos()); cg != nil && (i == 0 || cg != decl.Specs[i-1].(*ast.ValueSpec).Comment) {
+						vs.Doc = cg
+					}
+				}
+
+				if vs.Comment == nil {
+					vs.Comment = FindCommentLocationCommentGroup(fileNode, fileSet, vs.Pos())
+				}
+
+				if t := mapper(any(&ValueDecl{Spec: vs, GenDecl: decl}).(*From)); t != nil {
+					result = append(result, t)
+				}
+			}
 		}
 
 		return true
@@ -107,6 +187,34 @@ func InspectMapper[From any, To any](fileNode *ast.File, fileSet *token.FileSet,
 	return result
 }
 
+// fieldList returns the fields of a struct or interface type, and whether a field is an interface method.
+func fieldList(node ast.Node) (*ast.FieldList, func(*ast.Field) bool) {
+	if it, ok := node.(*ast.InterfaceType); ok {
+		return it.Methods, func(field *ast.Field) bool {
+			_, ok := field.Type.(*ast.FuncType)
+			return ok
+		}
+	}
+
+	return node.(*ast.StructType).Fields, func(*ast.Field) bool { return false }
+}
+
+// resolveFieldComments sets the doc and line comment groups of the fields not set by the parser. The line comment of a
+// field is not taken as the doc of the next field.
+func resolveFieldComments(fileNode *ast.File, fileSet *token.FileSet, fields *ast.FieldList) {
+	for i, field := range fields.List {
+		if field.Doc == nil {
+			if cg := FindDocLocationCommentGroup(fileNode, fileSet, field.Pos()); cg != nil && (i == 0 || cg != fields.List[i-1].Comment) {
+				field.Doc = cg
+			}
+		}
+
+		if field.Comment == nil {
+			field.Comment = FindCommentLocationCommentGroup(fileNode, fileSet, field.Pos())
+		}
+	}
+}
+
 func FindDocLocationCommentGroup(fileNode *ast.File, fileSet *token.FileSet, pos token.Pos) *ast.CommentGroup {
 	indentPos := fileSet.Position(pos)
 
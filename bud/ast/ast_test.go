@@ -2,9 +2,11 @@ package ast
 
 import (
 	"fmt"
+	"github.com/stretchr/testify/assert"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"strings"
 	"testing"
 )
 
@@ -124,3 +126,74 @@ func abc(){}
 		return nil
 	})
 }
+
+func TestInspectFieldAndValueDecl(t *testing.T) {
+	const src = `package main
+
+type User struct {
+	// @Column(name=id)
+	ID int
+	Name string // @Column(name=user_name)
+	Age int
+	Base
+	*other.Model
+}
+
+type Repo interface {
+	// @Inject
+	Find(id int) User
+	Save(u User) error // @Transactional
+}
+
+// @Version
+const Version = "1"
+
+const (
+	// @Default
+	A = iota
+	B // @Skip
+	C
+)
+
+var Global, Other int // @Validate
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	assert.NoError(t, err)
+
+	fields := InspectMapper[FieldDecl, string](file, fset, func(fd *FieldDecl) *string {
+		s := fmt.Sprintf("%s.%s method=%v doc=%q comment=%q", fd.TypeSpec.Name.Name, strings.Join(fd.Names(), ","), fd.Method,
+			strings.TrimSpace(fd.Field.Doc.Text()), strings.TrimSpace(fd.Field.Comment.Text()))
+		return &s
+	})
+	assert.Equal(t, []string{
+		`User.ID method=false doc="@Column(name=id)" comment=""`,
+		`User.Name method=false doc="" comment="@Column(name=user_name)"`,
+		`User.Age method=false doc="" comment=""`,
+		`User.Base method=false doc="" comment=""`,
+		`User.Model method=false doc="" comment=""`,
+		`Repo.Find method=true doc="@Inject" comment=""`,
+		`Repo.Save method=true doc="" comment="@Transactional"`,
+	}, stringValues(fields))
+
+	values := InspectMapper[ValueDecl, string](file, fset, func(vd *ValueDecl) *string {
+		s := fmt.Sprintf("%s %s doc=%q comment=%q", vd.GenDecl.Tok, vd.Spec.Names[0].Name,
+			strings.TrimSpace(vd.Spec.Doc.Text()), strings.TrimSpace(vd.Spec.Comment.Text()))
+		return &s
+	})
+	assert.Equal(t, []string{
+		`const Version doc="@Version" comment=""`,
+		`const A doc="@Default" comment=""`,
+		`const B doc="" comment="@Skip"`,
+		`const C doc="" comment=""`,
+		`var Global doc="" comment="@Validate"`,
+	}, stringValues(values))
+}
+
+func stringValues(values []*string) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = *v
+	}
+	return result
+}
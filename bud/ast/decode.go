@@ -0,0 +1,296 @@
+package ast
+
+import (
+	"encoding"
+	"fmt"
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/peace0phmind/bud/structure"
+	"go/token"
+	"reflect"
+	"strings"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decodeParams sets params to the fields of the struct value, owner names the annotation or map holding the params
+// in errors. The fields of an embedded struct are decoded as fields of the struct.
+// Dotted params like db.name=x are decoded into the field db like the map {name: x}, merged into the map or nested
+// annotation given as db.
+func decodeParams(structValue reflect.Value, owner string, params []*AnnotationParam) error {
+	var diagnostics Diagnostics
+
+	params, err := groupDottedParams(params)
+	if err != nil {
+		return err
+	}
+
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			diagnostics.Append(decodeParams(fieldValue, owner, params), token.Position{})
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		ps := newParamSchema(field)
+		if ps == nil {
+			continue
+		}
+
+		for _, p := range params {
+			if !ps.Is(p.Key.Text) {
+				continue
+			}
+
+			// a param given twice in a map is decoded in order, so the later one wins
+			name := owner + " " + p.Key.Text
+			diagnostics.Append(withPosition(decodeField(structValue, field, fieldValue, name, p.Value), p.Key.Pos, name), token.Position{})
+		}
+	}
+
+	return diagnostics.Err()
+}
+
+// groupDottedParams replaces the dotted params by a map param for each key before the first dot.
+func groupDottedParams(params []*AnnotationParam) ([]*AnnotationParam, error) {
+	var result []*AnnotationParam
+	grouped := map[string]*AnnotationParam{}
+
+	for _, p := range params {
+		head, rest, dotted := strings.Cut(p.Key.Text, ".")
+		if !dotted && !hasDotted(params, head) {
+			result = append(result, p)
+			continue
+		}
+
+		g, ok := grouped[strings.ToLower(head)]
+		if !ok {
+			g = &AnnotationParam{Pos: p.Pos, Key: Key{Pos: p.Key.Pos, Text: head}, Value: Map{}}
+			grouped[strings.ToLower(head)] = g
+			result = append(result, g)
+		}
+
+		m := g.Value.(Map)
+		if dotted {
+			m.Entries = append(m.Entries, &MapEntry{Pos: p.Key.Pos, Key: rest, Value: p.Value})
+		} else {
+			switch v := p.Value.(type) {
+			case Map:
+				m.Entries = append(v.Entries, m.Entries...)
+			case NestedAnnotation:
+				var entries []*MapEntry
+				if v.Params != nil {
+					for _, np := range v.Params.List {
+						entries = append(entries, &MapEntry{Pos: np.Key.Pos, Key: np.Key.Text, Value: np.Value})
+					}
+				}
+				m.Entries = append(entries, m.Entries...)
+			default:
+				return nil, Errorf(LexerPosition(p.Key.Pos), "param %s can not be combined with the params %s.*, use a map", p.Key.Text, head)
+			}
+		}
+		g.Value = m
+	}
+
+	return result, nil
+}
+
+// hasDotted reports whether one of the params is a dotted param of head.
+func hasDotted(params []*AnnotationParam, head string) bool {
+	for _, p := range params {
+		if h, _, dotted := strings.Cut(p.Key.Text, "."); dotted && strings.EqualFold(h, head) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeField sets value to the field of the struct value, using the Set method of the field if the struct has one.
+// name is the param of the field in errors.
+func decodeField(structValue reflect.Value, field reflect.StructField, fieldValue reflect.Value, name string, value Value) error {
+	if value == nil && fieldValue.Kind() == reflect.Bool {
+		value = Bool{V: true}
+	}
+
+	if value != nil && isScalarKind(fieldValue.Kind()) && structValue.CanAddr() {
+		converted, err := convertScalar(value, fieldValue.Type())
+		if err != nil {
+			return err
+		}
+		if structure.SetFieldBySetMethod(fieldValue, converted, field, structValue) {
+			return nil
+		}
+	}
+
+	return decodeValue(fieldValue, name, value)
+}
+
+// decodeValue sets value to target: a list to a slice, a map to a map or a struct, and a nested annotation to a
+// struct. A single value is decoded into a slice of one element, and a nil value is true for a bool.
+func decodeValue(target reflect.Value, name string, value Value) error {
+	if value == nil {
+		if target.Kind() != reflect.Bool {
+			return fmt.Errorf("a %s value is required", target.Type())
+		}
+		value = Bool{V: true}
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return decodeValue(target.Elem(), name, value)
+
+	case reflect.Struct:
+		switch v := value.(type) {
+		case NestedAnnotation:
+			var params []*AnnotationParam
+			if v.Params != nil {
+				params = v.Params.List
+			}
+			if err := checkUnknownParams(target.Type(), "@"+v.Name.Text, params); err != nil {
+				return err
+			}
+			return decodeParams(target, "@"+v.Name.Text, params)
+		case Map:
+			if err := checkUnknownParams(target.Type(), name, v.Params()); err != nil {
+				return err
+			}
+			return decodeParams(target, name, v.Params())
+		case String:
+			if reflect.PtrTo(target.Type()).Implements(textUnmarshalerType) {
+				return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v.V))
+			}
+		}
+		return fmt.Errorf("%v can not be decoded into %s, use a map or an annotation", value.Value(), target.Type())
+
+	case reflect.Slice:
+		items := []Value{value}
+		if list, ok := value.(List); ok {
+			items = list.V
+		}
+
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeValue(slice.Index(i), fmt.Sprintf("%s[%d]", name, i), item); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+		return nil
+
+	case reflect.Map:
+		m, ok := value.(Map)
+		if !ok {
+			return fmt.Errorf("%v can not be decoded into %s, use a map", value.Value(), target.Type())
+		}
+
+		if target.IsNil() {
+			target.Set(reflect.MakeMapWithSize(target.Type(), len(m.Entries)))
+		}
+		for _, e := range m.Entries {
+			key, err := convertScalar(String{V: e.Key}, target.Type().Key())
+			if err != nil {
+				return Errorf(LexerPosition(e.Pos), "%s key %s: %v", name, e.Key, err)
+			}
+
+			elem := reflect.New(target.Type().Elem()).Elem()
+			if err = decodeValue(elem, name+"."+e.Key, e.Value); err != nil {
+				return withPosition(err, e.Pos, name+"."+e.Key)
+			}
+			target.SetMapIndex(reflect.ValueOf(key), elem)
+		}
+		return nil
+
+	case reflect.Interface:
+		return setValue(target, reflect.ValueOf(value.Value()))
+
+	default:
+		converted, err := convertScalar(value, target.Type())
+		if err != nil {
+			return err
+		}
+		return setValue(target, reflect.ValueOf(converted))
+	}
+}
+
+// convertScalar converts value to the type t, a string is decoded by the encoding.TextUnmarshaler of t if t has one.
+// A number is converted to a named number type like time.Duration by its kind.
+func convertScalar(value Value, t reflect.Type) (any, error) {
+	if s, ok := value.(String); ok && reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		result := reflect.New(t)
+		if err := result.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s.V)); err != nil {
+			return nil, err
+		}
+		return result.Elem().Interface(), nil
+	}
+
+	converted, err := structure.ConvertToType(value.Value(), t)
+	if err == nil || t.PkgPath() == "" {
+		return converted, err
+	}
+
+	if kindValue, kindErr := structure.ConvertToKind(value.Value(), t.Kind()); kindErr == nil {
+		return reflect.ValueOf(kindValue).Convert(t).Interface(), nil
+	}
+	return nil, err
+}
+
+// checkUnknownParams reports the params not matching any field of structType.
+func checkUnknownParams(structType reflect.Type, owner string, params []*AnnotationParam) error {
+	schema := newSchema(structType, owner)
+
+	var diagnostics Diagnostics
+	for _, p := range params {
+		head, _, _ := strings.Cut(p.Key.Text, ".")
+		if schema.Param(head) == nil {
+			diagnostics.Add(Errorf(LexerPosition(p.Key.Pos), "unknown param %s of %s%s", head, owner, schema.suggest(head)))
+		}
+	}
+	return diagnostics.Err()
+}
+
+func setValue(target reflect.Value, value reflect.Value) error {
+	if !value.IsValid() {
+		return nil
+	}
+	if !value.Type().ConvertibleTo(target.Type()) {
+		return fmt.Errorf("%v is not assignable to %s", value, target.Type())
+	}
+	target.Set(value.Convert(target.Type()))
+	return nil
+}
+
+// withPosition returns err of the param name as a Diagnostic at pos, unless it already is one.
+func withPosition(err error, pos lexer.Position, name string) error {
+	if err == nil || isDiagnostic(err) {
+		return err
+	}
+	return Errorf(LexerPosition(pos), "%s: %v", name, err)
+}
+
+func isDiagnostic(err error) bool {
+	switch err.(type) {
+	case *Diagnostic, Diagnostics:
+		return true
+	default:
+		return false
+	}
+}
+
+// isScalarKind reports whether a value of kind is decoded by converting a single value.
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Struct, reflect.Slice, reflect.Map, reflect.Array, reflect.Interface:
+		return false
+	default:
+		return true
+	}
+}
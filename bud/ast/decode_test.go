@@ -0,0 +1,140 @@
+package ast
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type decodePoint struct {
+	X int
+	Y int
+}
+
+type decodeInner struct {
+	Size int
+	Flag bool
+}
+
+type decodeOptions struct {
+	Aliases []string
+	Labels  map[string]string
+	Inner   *decodeInner
+	Point   decodePoint
+	Sizes   map[string][]int
+	Items   []decodePoint
+}
+
+func TestAnnotationParamsToComposite(t *testing.T) {
+	parse := func(text string) *Annotation {
+		ag, err := ParseAnnotation("a.go", text)
+		assert.NoError(t, err)
+		return ag.Annotations[0]
+	}
+
+	a := parse(`@Opt(aliases=[a, "b c"], labels={en: "Red", zh: "红"}, inner=@Inner(size=2, flag), point={x: 1, y: 2},
+		sizes={small: [1, 2], large: 3}, items=[{x: 1}, {y: 2}])`)
+	assert.Equal(t, []any{"a", "b c"}, a.Params.List[0].Value.Value())
+	assert.Equal(t, map[string]any{"en": "Red", "zh": "红"}, a.Params.List[1].Value.Value())
+
+	o, err := AnnotationParamsTo[decodeOptions](nil, a)
+	assert.NoError(t, err)
+	assert.Equal(t, &decodeOptions{
+		Aliases: []string{"a", "b c"},
+		Labels:  map[string]string{"en": "Red", "zh": "红"},
+		Inner:   &decodeInner{Size: 2, Flag: true},
+		Point:   decodePoint{X: 1, Y: 2},
+		Sizes:   map[string][]int{"small": {1, 2}, "large": {3}},
+		Items:   []decodePoint{{X: 1}, {Y: 2}},
+	}, o)
+
+	o, err = AnnotationParamsTo[decodeOptions](nil, parse("@Opt(aliases=a)"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, o.Aliases)
+
+	_, err = AnnotationParamsTo[decodeOptions](nil, parse("@Opt(inner=@Inner(sise=2))"))
+	assert.EqualError(t, err, "a.go:1:19: error: unknown param sise of @Inner, did you mean Size?")
+
+	_, err = AnnotationParamsTo[decodeOptions](nil, parse("@Opt(point={x: 1, z: 2})"))
+	assert.EqualError(t, err, "a.go:1:19: error: unknown param z of @Opt point")
+
+	_, err = AnnotationParamsTo[decodeOptions](nil, parse("@Opt(labels=[a])"))
+	assert.EqualError(t, err, "a.go:1:6: error: @Opt labels: [a] can not be decoded into map[string]string, use a map")
+
+	_, err = AnnotationParamsTo[decodeOptions](nil, parse("@Opt(sizes={small: [1, x]})"))
+	assert.EqualError(t, err, "a.go:1:13: error: @Opt sizes.small: cannot parse 'x' as int: strconv.ParseInt: parsing \"x\": invalid syntax")
+}
+
+type decodeLevel int
+
+func (l *decodeLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %s", text)
+	}
+	return nil
+}
+
+type decodeDB struct {
+	Name    string
+	Port    int
+	Pool    *decodeInner
+	Enabled bool
+}
+
+type decodeRich struct {
+	DB      decodeDB          `annotation:"db"`
+	Cache   *decodeDB         `annotation:"cache,alias=c"`
+	Timeout time.Duration     `annotation:"timeout"`
+	Retry   time.Duration     `annotation:"retry"`
+	Level   decodeLevel       `annotation:"level"`
+	Levels  []decodeLevel     `annotation:"levels"`
+	Labels  map[string]string `annotation:"labels"`
+}
+
+func TestAnnotationParamsToRich(t *testing.T) {
+	RegisterSchema(NewSchema[decodeRich]("Rich"))
+
+	parse := func(text string) *Annotation {
+		ag, err := ParseAnnotation("a.go", text)
+		assert.NoError(t, err)
+		return ag.Annotations[0]
+	}
+
+	r, err := AnnotationParamsTo[decodeRich](nil, parse(`@Rich(db.name=x, db.port=5432, db.pool.size=4, db.enabled, c.name=y,
+		timeout="1m30s", retry=5, level=high, levels=[low, high], labels.en=Red, labels={zh: 红})`))
+	assert.NoError(t, err)
+	assert.Equal(t, &decodeRich{
+		DB:      decodeDB{Name: "x", Port: 5432, Pool: &decodeInner{Size: 4}, Enabled: true},
+		Cache:   &decodeDB{Name: "y"},
+		Timeout: 90 * time.Second,
+		Retry:   5,
+		Level:   2,
+		Levels:  []decodeLevel{1, 2},
+		Labels:  map[string]string{"en": "Red", "zh": "红"},
+	}, r)
+
+	r, err = AnnotationParamsTo[decodeRich](nil, parse(`@Rich(db={name: x, port: 1}, db.port=2)`))
+	assert.NoError(t, err)
+	assert.Equal(t, decodeDB{Name: "x", Port: 2}, r.DB)
+
+	_, err = AnnotationParamsTo[decodeRich](nil, parse(`@Rich(db.nmae=x, timeout.x=1)`))
+	assert.EqualError(t, err, "a.go:1:18: error: param timeout of @Rich is time.Duration, it has no param x")
+
+	_, err = AnnotationParamsTo[decodeRich](nil, parse(`@Rich(db.nmae=x)`))
+	assert.EqualError(t, err, "a.go:1:7: error: unknown param nmae of @Rich db, did you mean Name?")
+
+	_, err = AnnotationParamsTo[decodeRich](nil, parse(`@Rich(level=medium)`))
+	assert.EqualError(t, err, "a.go:1:7: error: param level of @Rich must be ast.decodeLevel, got medium")
+
+	_, err = AnnotationParamsTo[decodeRich](nil, parse(`@Rich(timeout="abc")`))
+	assert.EqualError(t, err, "a.go:1:7: error: param timeout of @Rich must be time.Duration, got abc")
+
+	_, err = AnnotationParamsTo[decodeRich](nil, parse(`@Rich(db=x, db.port=2)`))
+	assert.EqualError(t, err, "a.go:1:7: error: param db can not be combined with the params db.*, use a map")
+}
@@ -0,0 +1,164 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+	"go/scanner"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Diagnostic is an error or a warning located in a source file.
+type Diagnostic struct {
+	Pos      token.Position
+	Severity Severity
+	Message  string
+}
+
+// Errorf returns an error Diagnostic at pos.
+func Errorf(pos token.Position, format string, args ...any) *Diagnostic {
+	return &Diagnostic{Pos: pos, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+// Warningf returns a warning Diagnostic at pos.
+func Warningf(pos token.Position, format string, args ...any) *Diagnostic {
+	return &Diagnostic{Pos: pos, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)}
+}
+
+// LexerPosition converts the position recorded by the annotation parser to a token.Position.
+func LexerPosition(pos lexer.Position) token.Position {
+	return token.Position{Filename: pos.Filename, Offset: pos.Offset, Line: pos.Line, Column: pos.Column}
+}
+
+// Error formats the diagnostic in compiler style: "file:line:col: severity: message".
+func (d *Diagnostic) Error() string {
+	if d.Pos.Filename == "" && !d.Pos.IsValid() {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message)
+}
+
+// Diagnostics collects the errors and warnings of a generation.
+type Diagnostics []*Diagnostic
+
+func (ds Diagnostics) Error() string {
+	lines := make([]string, len(ds))
+	for i, d := range ds {
+		lines[i] = d.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Add appends diagnostics to ds.
+func (ds *Diagnostics) Add(diagnostics ...*Diagnostic) {
+	*ds = append(*ds, diagnostics...)
+}
+
+// Append appends err to ds. Diagnostics, go scanner errors and annotation parse errors keep their own positions,
+// any other error is reported at pos. Joined errors are appended one by one.
+func (ds *Diagnostics) Append(err error, pos token.Position) {
+	if err == nil {
+		return
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			ds.Append(e, pos)
+		}
+		return
+	}
+
+	var diagnostics Diagnostics
+	var diagnostic *Diagnostic
+	var scannerErrors scanner.ErrorList
+	var scannerError *scanner.Error
+	var parseError participle.Error
+
+	switch {
+	case errors.As(err, &diagnostics):
+		ds.Add(diagnostics...)
+	case errors.As(err, &diagnostic):
+		ds.Add(diagnostic)
+	case errors.As(err, &scannerErrors):
+		for _, e := range scannerErrors {
+			ds.Add(Errorf(e.Pos, "%s", e.Msg))
+		}
+	case errors.As(err, &scannerError):
+		ds.Add(Errorf(scannerError.Pos, "%s", scannerError.Msg))
+	case errors.As(err, &parseError):
+		ds.Add(Errorf(LexerPosition(parseError.Position()), "%s", parseError.Message()))
+	default:
+		ds.Add(Errorf(pos, "%s", err.Error()))
+	}
+}
+
+// Warnings returns err as diagnostics like Append, with their severity lowered to warnings.
+func Warnings(err error, pos token.Position) Diagnostics {
+	var ds Diagnostics
+	ds.Append(err, pos)
+	for _, d := range ds {
+		d.Severity = SeverityWarning
+	}
+	return ds
+}
+
+// HasErrors reports whether ds contains at least one error.
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns ds as an error, or nil if ds is empty.
+func (ds Diagnostics) Err() error {
+	if len(ds) == 0 {
+		return nil
+	}
+	return ds
+}
+
+// Sort sorts ds by file name, line and column.
+func (ds Diagnostics) Sort() {
+	sort.SliceStable(ds, func(i, j int) bool {
+		a, b := ds[i].Pos, ds[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// Print writes every diagnostic on its own line to w.
+func (ds Diagnostics) Print(w io.Writer) {
+	for _, d := range ds {
+		_, _ = fmt.Fprintln(w, d.Error())
+	}
+}
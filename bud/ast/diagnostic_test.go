@@ -0,0 +1,63 @@
+package ast
+
+import (
+	"errors"
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/stretchr/testify/assert"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestParseCommentGroupPosition(t *testing.T) {
+	const src = `package main
+
+//go:generate go run main.go
+// MyEnum doc
+/* @ENUM(code int) {
+	a(1) */
+//	b(2)
+// }
+type MyEnum int
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "my_enum.go", src, parser.ParseComments)
+	assert.NoError(t, err)
+
+	ag, err := ParseCommentGroup(fset, file.Comments[0])
+	assert.NoError(t, err)
+
+	a := ag.FindAnnotationByName("enum")
+	assert.NotNil(t, a)
+	assert.Equal(t, token.Position{Filename: "my_enum.go", Line: 5, Column: 5}, toPosition(a.Name.Pos))
+	assert.Equal(t, token.Position{Filename: "my_enum.go", Line: 5, Column: 10}, toPosition(a.Params.List[0].Key.Pos))
+	assert.Equal(t, token.Position{Filename: "my_enum.go", Line: 6, Column: 2}, toPosition(a.Extends.List[0].Name.Pos))
+	assert.Equal(t, token.Position{Filename: "my_enum.go", Line: 7, Column: 4}, toPosition(a.Extends.List[1].Name.Pos))
+}
+
+func toPosition(pos lexer.Position) token.Position {
+	p := LexerPosition(pos)
+	p.Offset = 0
+	return p
+}
+
+func TestDiagnosticsAppend(t *testing.T) {
+	var ds Diagnostics
+
+	_, err := ParseAnnotation("file.go", "@ENUM{ x(")
+	ds.Append(err, token.Position{Filename: "other.go"})
+	ds.Append(errors.Join(errors.New("plain"), Warningf(token.Position{Filename: "file.go", Line: 2, Column: 1}, "warn")), token.Position{Filename: "other.go"})
+	ds.Append(nil, token.Position{})
+
+	assert.Len(t, ds, 3)
+	assert.True(t, ds.HasErrors())
+	assert.Equal(t, "file.go:1:9: error: unexpected token \"(\" (expected ClosedBracket)", ds[0].Error())
+	assert.Equal(t, "other.go: error: plain", ds[1].Error())
+	assert.Equal(t, "file.go:2:1: warning: warn", ds[2].Error())
+
+	ds.Sort()
+	assert.Equal(t, "file.go:1:9: error: unexpected token \"(\" (expected ClosedBracket)\nfile.go:2:1: warning: warn\nother.go: error: plain", ds.Error())
+
+	assert.False(t, Diagnostics{ds[1]}.HasErrors())
+	assert.Nil(t, Diagnostics(nil).Err())
+}
@@ -0,0 +1,229 @@
+package ast
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// importFileSet holds the files of the packages imported by sourceImporter.
+var importFileSet = token.NewFileSet()
+
+// sourceImporter imports packages from source, it is shared by all files so every package is only imported once.
+var sourceImporter = importer.ForCompiler(importFileSet, "source", nil).(types.ImporterFrom)
+
+// ImportedPosition returns the position of pos in a package imported from source, like the declaration of a type
+// returned by File.LookupType or File.Eval.
+func ImportedPosition(pos token.Pos) token.Position {
+	return importFileSet.Position(pos)
+}
+
+// File is a parsed source file handed to the generators.
+type File struct {
+	Node    *goast.File
+	FileSet *token.FileSet
+	// PackageFiles are all the files of the package, including Node and the generated files, used for type checking.
+	PackageFiles []*goast.File
+	// Defaults are the default annotations of the file set by the project configuration, like a default @EnumConfig.
+	// The annotations of the file override them.
+	Defaults AnnotationGroup
+	// MetaAnnotations are the meta-annotations of the project configuration, expanded by ParseCommentGroup besides the
+	// registered ones.
+	MetaAnnotations []*MetaAnnotation
+
+	checkOnce sync.Once
+	pkg       *types.Package
+	info      *types.Info
+}
+
+// NewFile returns the File of fileNode, packageFiles are the files of its package.
+func NewFile(fileNode *goast.File, fileSet *token.FileSet, packageFiles []*goast.File) *File {
+	if len(packageFiles) == 0 {
+		packageFiles = []*goast.File{fileNode}
+	}
+
+	return &File{Node: fileNode, FileSet: fileSet, PackageFiles: packageFiles}
+}
+
+// Name returns the file name of the file.
+func (f *File) Name() string {
+	return f.FileSet.Position(f.Node.Package).Filename
+}
+
+// ParseCommentGroup parses the annotations of the comment group, and expands the meta-annotations.
+// The parse error of a comment group which is not Annotated is returned as warnings, it is taken as prose holding an "@".
+func (f *File) ParseCommentGroup(cg *goast.CommentGroup) (*AnnotationGroup, error) {
+	ag, err := ParseCommentGroup(f.FileSet, cg)
+	if err != nil {
+		if !f.Annotated(cg) {
+			return ag, Warnings(err, f.FileSet.Position(cg.Pos()))
+		}
+		return ag, err
+	}
+
+	return ag, ag.ExpandMetaAnnotations(f.MetaAnnotations...)
+}
+
+// Annotated reports whether a line of the comment group starts with a registered annotation, an annotation with a
+// schema or a meta-annotation like @EnumConfig. The errors of a comment group which is not annotated are reported as
+// warnings, it may be prose mentioning an annotation like @ENUM in the middle of a sentence.
+func (f *File) Annotated(cg *goast.CommentGroup) bool {
+	_, text := commentGroupText(f.FileSet, cg)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		name := line[1:]
+		if i := strings.IndexFunc(name, func(r rune) bool { return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) }); i >= 0 {
+			name = name[:i]
+		}
+		if len(name) > 0 && (GetSchema(name) != nil || GetMetaAnnotation(name) != nil || f.metaAnnotation(name) != nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// metaAnnotation returns the meta-annotation name of the project configuration, or nil if not found.
+func (f *File) metaAnnotation(name string) *MetaAnnotation {
+	for _, meta := range f.MetaAnnotations {
+		if strings.EqualFold(meta.Name, name) {
+			return meta
+		}
+	}
+	return nil
+}
+
+// Types type checks the package of the file on first use, and returns the type information.
+// Type errors are ignored, because the package may not compile before its bud files are generated.
+func (f *File) Types() (*types.Package, *types.Info) {
+	f.checkOnce.Do(func() {
+		f.info = &types.Info{
+			Types:  map[goast.Expr]types.TypeAndValue{},
+			Defs:   map[*goast.Ident]types.Object{},
+			Uses:   map[*goast.Ident]types.Object{},
+			Scopes: map[goast.Node]*types.Scope{},
+		}
+
+		conf := types.Config{
+			Importer: sourceImporter,
+			Error:    func(err error) {},
+		}
+
+		f.pkg, _ = conf.Check(f.Node.Name.Name, f.FileSet, f.PackageFiles, f.info)
+	})
+
+	return f.pkg, f.info
+}
+
+// Eval evaluates the go expression expr, which can also be a type, in the scope of the file at pos.
+// Besides the packages imported by the file, a qualified identifier can refer to a package imported by another file
+// of the package, or a package whose import path is the qualifier like "time".
+// It returns the import paths of the packages used by expr, a package used by a name other than its own is returned
+// as `name path`.
+func (f *File) Eval(pos token.Pos, expr string) (tv types.TypeAndValue, imports []string, err error) {
+	x, err := parser.ParseExpr(expr)
+	if err != nil {
+		return tv, nil, fmt.Errorf("parse %s: %w", expr, err)
+	}
+
+	pkg, info := f.Types()
+	fileScope := info.Scopes[f.Node]
+	if pkg == nil || fileScope == nil {
+		return tv, nil, fmt.Errorf("eval %s: package %s is not type checked", expr, f.Node.Name.Name)
+	}
+
+	importSet := map[string]bool{}
+	goast.Inspect(x, func(n goast.Node) bool {
+		if se, ok := n.(*goast.SelectorExpr); ok {
+			if qualifier, ok := se.X.(*goast.Ident); ok {
+				_, obj := fileScope.LookupParent(qualifier.Name, pos)
+				if obj == nil {
+					obj = f.importQualifier(fileScope, qualifier.Name)
+				}
+
+				if pkgName, ok := obj.(*types.PkgName); ok {
+					imp := pkgName.Imported()
+					if imp.Name() == pkgName.Name() {
+						importSet[imp.Path()] = true
+					} else {
+						importSet[pkgName.Name()+" "+imp.Path()] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	for imp := range importSet {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+
+	tv, err = types.Eval(f.FileSet, pkg, pos, expr)
+	return tv, imports, err
+}
+
+// importQualifier imports the package of a qualifier not imported by the file, and declares it in the file scope.
+func (f *File) importQualifier(fileScope *types.Scope, qualifier string) types.Object {
+	type candidate struct {
+		path  string
+		alias bool
+	}
+
+	candidates := []candidate{{path: qualifier}}
+	for _, pf := range f.PackageFiles {
+		for _, spec := range pf.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			if spec.Name != nil && spec.Name.Name == qualifier {
+				candidates = append([]candidate{{path: importPath, alias: true}}, candidates...)
+			} else if spec.Name == nil && path.Base(importPath) == qualifier {
+				candidates = append([]candidate{{path: importPath}}, candidates...)
+			}
+		}
+	}
+
+	for _, c := range candidates {
+		imported, err := sourceImporter.ImportFrom(c.path, filepath.Dir(f.Name()), 0)
+		if err == nil && (c.alias || imported.Name() == qualifier) {
+			pkgName := types.NewPkgName(token.NoPos, f.pkg, qualifier, imported)
+			fileScope.Insert(pkgName)
+			return pkgName
+		}
+	}
+
+	return nil
+}
+
+// LookupType returns the named type name of the package importPath, which is imported from source relative to the
+// file, so it does not have to be imported by the package of the file.
+func (f *File) LookupType(importPath string, name string) (*types.Named, error) {
+	pkg, err := sourceImporter.ImportFrom(importPath, filepath.Dir(f.Name()), 0)
+	if err != nil {
+		return nil, fmt.Errorf("import %s: %w", importPath, err)
+	}
+
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a type of package %s", name, importPath)
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s.%s is not a named type", importPath, name)
+	}
+
+	return named, nil
+}
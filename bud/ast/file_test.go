@@ -0,0 +1,103 @@
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileEval(t *testing.T) {
+	fileSet := token.NewFileSet()
+	a, err := parser.ParseFile(fileSet, "a.go", `package p
+
+type Color int
+
+const ColorRed Color = 1
+`, parser.ParseComments)
+	assert.NoError(t, err)
+
+	b, err := parser.ParseFile(fileSet, "b.go", `package p
+
+import tm "time"
+
+var _ tm.Duration
+`, parser.ParseComments)
+	assert.NoError(t, err)
+
+	file := NewFile(a, fileSet, []*goast.File{a, b})
+
+	tv, imports, err := file.Eval(a.Package, "Color")
+	assert.NoError(t, err)
+	assert.True(t, tv.IsType())
+	assert.Empty(t, imports)
+
+	tv, _, err = file.Eval(a.Package, "ColorRed")
+	assert.NoError(t, err)
+	assert.True(t, tv.IsValue())
+	assert.Equal(t, "p.Color", tv.Type.String())
+
+	tv, imports, err = file.Eval(a.Package, "time.Duration")
+	assert.NoError(t, err)
+	assert.True(t, tv.IsType())
+	assert.Equal(t, []string{"time"}, imports)
+
+	_, imports, err = file.Eval(a.Package, "tm.Second")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tm time"}, imports)
+
+	_, _, err = file.Eval(a.Package, "Unknown")
+	assert.Error(t, err)
+}
+
+func TestFileLookupType(t *testing.T) {
+	fileSet := token.NewFileSet()
+	a, err := parser.ParseFile(fileSet, "a.go", "package p\n", parser.ParseComments)
+	assert.NoError(t, err)
+	file := NewFile(a, fileSet, nil)
+
+	named, err := file.LookupType("time", "Month")
+	assert.NoError(t, err)
+	assert.Equal(t, "time.Month", named.String())
+	assert.Equal(t, "time.go", filepath.Base(ImportedPosition(named.Obj().Pos()).Filename))
+
+	_, err = file.LookupType("time", "Now")
+	assert.EqualError(t, err, "Now is not a type of package time")
+	_, err = file.LookupType("unknown/pkg", "Type")
+	assert.Error(t, err)
+}
+
+func TestFileParseCommentGroupProse(t *testing.T) {
+	RegisterSchema(&Schema{Name: "Prose", AllowUnknown: true})
+
+	src := `package p
+
+// Config is read from a file like
+//
+//	metaAnnotations:
+//	  DbEnum: "@Prose(sql, marshal)"
+type Config struct{}
+
+// Registry is the @Prose annotation, given in the comment group of //go:generate.
+type Registry struct{}
+
+// Broken is broken.
+// @Prose(a=[1)
+type Broken int
+`
+	fileSet := token.NewFileSet()
+	a, err := parser.ParseFile(fileSet, "a.go", src, parser.ParseComments)
+	assert.NoError(t, err)
+	file := NewFile(a, fileSet, nil)
+
+	for i, cg := range a.Comments {
+		_, err := file.ParseCommentGroup(cg)
+		var diagnostics Diagnostics
+		diagnostics.Append(err, fileSet.Position(cg.Pos()))
+		assert.Len(t, diagnostics, 1)
+		assert.Equal(t, i == 2, diagnostics.HasErrors(), "only the comment group starting with @Prose is an error")
+		assert.Equal(t, i == 2, file.Annotated(cg))
+	}
+}
@@ -0,0 +1,498 @@
+package ast
+
+import (
+	"fmt"
+	"github.com/alecthomas/participle/v2/lexer"
+	goast "go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Format returns the canonical text of the annotation without comment markers, one string per line.
+//
+// The params are written in one line as @Name(key, key=value), or one per line if a param has a comment. The items
+// of the braces are written in one line as @Name{a, b} if the annotation was written in one line without comments,
+// otherwise one item per line with the names, the values in parentheses, the "= value" and the comments aligned in
+// columns. The comments before the annotation are not part of it, the comments inside and after it are kept.
+func (a *Annotation) Format() []string {
+	lines := a.formatLines()
+	if a.Comment != nil {
+		lines[len(lines)-1] += " " + commentText(a.Comment)
+	}
+	return lines
+}
+
+// formatLines returns the lines of Format without the comment after the annotation.
+func (a *Annotation) formatLines() []string {
+	lines := formatParams("@"+a.Name.Text, a.Params)
+
+	if a.Extends != nil {
+		head := lines[len(lines)-1]
+		if a.Params != nil && len(a.Params.List) > 0 {
+			head += " "
+		}
+
+		if a.Extends.multiline(a.Name.Pos.Line) {
+			lines[len(lines)-1] = head + "{"
+			lines = append(lines, formatExtends(a.Extends.List)...)
+			lines = append(lines, "}")
+		} else {
+			items := make([]string, len(a.Extends.List))
+			for i, ex := range a.Extends.List {
+				items[i] = formatExtend(ex)
+			}
+			lines[len(lines)-1] = head + "{" + strings.Join(items, ", ") + "}"
+		}
+	}
+
+	// a nested annotation with commented params spans several lines
+	return strings.Split(strings.Join(lines, "\n"), "\n")
+}
+
+// formatParams returns the lines of the name followed by the params, the params without a comment are written
+// in the line of the name.
+func formatParams(name string, params *Params) []string {
+	if params == nil || len(params.List) == 0 {
+		return []string{name}
+	}
+
+	commented := false
+	items := make([]string, len(params.List))
+	for i, p := range params.List {
+		items[i] = formatParam(p)
+		commented = commented || p.Comment != nil || len(p.Comments) > 0 || strings.Contains(items[i], "\n")
+	}
+	if !commented {
+		return []string{name + "(" + strings.Join(items, ", ") + ")"}
+	}
+
+	lines := []string{name + "("}
+	for i, p := range params.List {
+		for _, c := range p.Comments {
+			lines = append(lines, commentText(c))
+		}
+		itemLines := strings.Split(items[i]+",", "\n")
+		if p.Comment != nil {
+			itemLines[len(itemLines)-1] += " " + commentText(p.Comment)
+		}
+		lines = append(lines, itemLines...)
+	}
+	return append(lines, ")")
+}
+
+func formatParam(p *AnnotationParam) string {
+	if p.Value == nil {
+		return p.Key.Text
+	}
+	if p.Key.Assign {
+		return p.Key.Text + "=" + FormatValue(p.Value)
+	}
+	return p.Key.Text + " " + FormatValue(p.Value)
+}
+
+// multiline reports whether the items are written one per line, as they were in the source or as a comment requires.
+func (e *Extends) multiline(line int) bool {
+	if e.ClosedBracket.Pos.Line != line {
+		return true
+	}
+	for _, ex := range e.List {
+		if ex.Comment != nil || len(ex.Comments) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// formatExtends returns the items one per line, aligned in columns.
+func formatExtends(list []*AnnotationExtend) []string {
+	values := make([][]string, len(list))
+	tableWidth := 0
+	for i, ex := range list {
+		for _, v := range ex.Values {
+			values[i] = append(values[i], FormatValue(v))
+		}
+		if len(values[i]) > tableWidth {
+			tableWidth = len(values[i])
+		}
+	}
+
+	// the names and the values are aligned like a table only if an item has more than one value
+	nameWidth := 0
+	var columnWidths []int
+	if tableWidth > 1 {
+		columnWidths = make([]int, tableWidth)
+		for i, ex := range list {
+			if len(values[i]) == 0 {
+				continue
+			}
+			nameWidth = maxWidth(nameWidth, ex.Name.Text)
+			for j, v := range values[i][:len(values[i])-1] {
+				columnWidths[j] = maxWidth(columnWidths[j], v+",")
+			}
+		}
+	}
+
+	items := make([]string, len(list))
+	itemWidth := 0
+	for i, ex := range list {
+		items[i] = ex.Name.Text
+		if len(values[i]) > 0 {
+			sb := strings.Builder{}
+			sb.WriteString(pad(ex.Name.Text, nameWidth) + "(")
+			for j, v := range values[i] {
+				if j == len(values[i])-1 {
+					sb.WriteString(v)
+				} else if columnWidths != nil {
+					sb.WriteString(pad(v+",", columnWidths[j]) + " ")
+				} else {
+					sb.WriteString(v + ", ")
+				}
+			}
+			sb.WriteString(")")
+			items[i] = sb.String()
+		}
+		if ex.Value != nil {
+			itemWidth = maxWidth(itemWidth, items[i])
+		}
+	}
+
+	transitionWidth := 0
+	for i, ex := range list {
+		if ex.Value != nil {
+			items[i] = pad(items[i], itemWidth) + " = " + FormatValue(ex.Value)
+		}
+		if len(ex.Transitions) > 0 {
+			transitionWidth = maxWidth(transitionWidth, items[i])
+		}
+	}
+
+	commentWidth := 0
+	for i, ex := range list {
+		if len(ex.Transitions) > 0 {
+			items[i] = pad(items[i], transitionWidth) + " -> " + formatNames(ex.Transitions)
+		}
+		if ex.Comment != nil {
+			commentWidth = maxWidth(commentWidth, items[i])
+		}
+	}
+
+	var lines []string
+	for i, ex := range list {
+		for _, c := range ex.Comments {
+			lines = append(lines, commentText(c))
+		}
+		if ex.Comment != nil {
+			items[i] = pad(items[i], commentWidth) + " " + commentText(ex.Comment)
+		}
+		lines = append(lines, items[i])
+	}
+	return lines
+}
+
+func formatExtend(ex *AnnotationExtend) string {
+	sb := strings.Builder{}
+	sb.WriteString(ex.Name.Text)
+	if len(ex.Values) > 0 {
+		sb.WriteString("(" + formatValues(ex.Values) + ")")
+	}
+	if ex.Value != nil {
+		sb.WriteString(" = " + FormatValue(ex.Value))
+	}
+	if len(ex.Transitions) > 0 {
+		// the brackets end the transitions before the next item in the same line
+		sb.WriteString(" -> [" + formatNames(ex.Transitions) + "]")
+	}
+	return sb.String()
+}
+
+func formatNames(names []*Name) string {
+	texts := make([]string, len(names))
+	for i, name := range names {
+		texts[i] = name.Text
+	}
+	return strings.Join(texts, ", ")
+}
+
+// FormatValue returns the canonical text of an annotation value. A scalar parsed from source keeps its literal, like
+// 0x0B or "red", other strings are quoted unless they are an identifier or a dotted name.
+func FormatValue(v Value) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case Bool:
+		return strconv.FormatBool(bool(v.V))
+	case Int:
+		if len(v.Tokens) > 0 {
+			return literal(v.Tokens)
+		}
+		return strconv.Itoa(v.V)
+	case Uint:
+		if len(v.Tokens) > 0 {
+			return literal(v.Tokens)
+		}
+		return strconv.FormatUint(uint64(v.V), 10)
+	case Float:
+		if len(v.Tokens) > 0 {
+			return literal(v.Tokens)
+		}
+		s := strconv.FormatFloat(v.V, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eIN") {
+			s += ".0"
+		}
+		return s
+	case String:
+		if len(v.Tokens) > 0 {
+			return literal(v.Tokens)
+		}
+		if isDottedName(v.V) {
+			return v.V
+		}
+		return strconv.Quote(v.V)
+	case List:
+		return "[" + formatValues(v.V) + "]"
+	case Map:
+		entries := make([]string, len(v.Entries))
+		for i, e := range v.Entries {
+			key := e.Key
+			if !token.IsIdentifier(key) {
+				key = strconv.Quote(key)
+			}
+			entries[i] = key + ": " + FormatValue(e.Value)
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case NestedAnnotation:
+		// a nested annotation with commented params spans several lines
+		return strings.Join(formatParams("@"+v.Name.Text, v.Params), "\n")
+	default:
+		return strconv.Quote(fmt.Sprint(v.Value()))
+	}
+}
+
+// literal returns the source text of the tokens of a scalar value without the trailing comma.
+func literal(tokens []lexer.Token) string {
+	stringType := annotationParser.Lexer().Symbols()["String"]
+
+	sb := strings.Builder{}
+	for _, t := range tokens {
+		switch {
+		case t.Type == stringType:
+			// the strings are unquoted by the lexer
+			sb.WriteString(strconv.Quote(t.Value))
+		case t.Value != ",":
+			sb.WriteString(t.Value)
+		}
+	}
+	return sb.String()
+}
+
+func formatValues(values []Value) string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = FormatValue(v)
+	}
+	return strings.Join(items, ", ")
+}
+
+// isDottedName reports whether s is read back as the same string without quotes, like red or time.Second.
+func isDottedName(s string) bool {
+	if s == "true" || s == "false" {
+		return false
+	}
+	for _, part := range strings.Split(s, ".") {
+		// the annotation lexer reads the go keywords as identifiers
+		if !token.IsIdentifier(part) && !token.Lookup(part).IsKeyword() {
+			return false
+		}
+	}
+	return true
+}
+
+func commentText(c *Comment) string {
+	return strings.TrimRight(c.Text, " \t")
+}
+
+func maxWidth(width int, s string) int {
+	if w := utf8.RuneCountInString(s); w > width {
+		return w
+	}
+	return width
+}
+
+func pad(s string, width int) string {
+	if n := width - utf8.RuneCountInString(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// FormatCommentGroup returns the source of the comment group, the text of src from cg.Pos() to cg.End(), with its
+// annotations in the canonical layout of Format. Only the text of an annotation from its "@" to its closing parenthesis
+// or bracket is rewritten, the text around it and the comment markers and indentation of the lines are kept as they
+// are. An "@" which is not written right before a name following a space, like in "@" or user@host, does not start an
+// annotation, and an annotation spanning a block comment and other comments is left as it is.
+// A group which can not be parsed is returned as it is, the error is only returned if a line of it starts with an
+// annotation, otherwise it is taken as a plain comment.
+func FormatCommentGroup(fileSet *token.FileSet, cg *goast.CommentGroup, src []byte) (string, error) {
+	file := fileSet.File(cg.Pos())
+	start, end := file.Offset(cg.Pos()), file.Offset(cg.End())
+	result := string(src[start:end])
+
+	fileName, text := commentGroupText(fileSet, cg)
+	textLines := strings.Split(text, "\n")
+	ag, err := ParseAnnotation(fileName, text)
+	if err != nil {
+		if startsWithAnnotation(textLines) {
+			return "", err
+		}
+		return result, nil
+	}
+
+	// the offset in the group of a rune column of a line, the text has the same bytes as the source in the comments
+	offset := func(line, column int) int {
+		runes := []rune(textLines[line-1])
+		return file.Offset(file.LineStart(line)) + len(string(runes[:minInt(column, len(runes))])) - start
+	}
+	// the offset of the start of a line in src, the first line of the group starts at the group
+	lineStart := func(line int) int {
+		if from := file.Offset(file.LineStart(line)); from > start {
+			return from
+		}
+		return start
+	}
+	// the comment markers and the indentation of a line
+	prefix := func(line int) string {
+		content := len(textLines[line-1]) - len(strings.TrimLeftFunc(textLines[line-1], unicode.IsSpace))
+		return string(src[lineStart(line) : file.Offset(file.LineStart(line))+content])
+	}
+	// the source of a line in the group without its newline
+	rawLine := func(line int) string {
+		from := lineStart(line)
+		return string(src[from : from+strings.IndexByte(string(src[from:end])+"\n", '\n')])
+	}
+	blank := func(line int) bool {
+		return len(strings.TrimSpace(textLines[line-1])) == 0
+	}
+
+	// the annotations are replaced from the last one, so the offsets of the previous ones stay valid
+	for i := len(ag.Annotations) - 1; i >= 0; i-- {
+		a := ag.Annotations[i]
+		startLine, endLine := a.Name.Pos.Line, a.endLine()
+		if !a.written(textLines) || !inOneBlock(fileSet, cg, startLine, endLine) {
+			continue
+		}
+
+		lines := a.formatLines()
+
+		// the blank lines are kept as they are, gofmt takes indented lines after a blank line for a code block
+		var filled []int
+		for line := startLine + 1; line <= endLine; line++ {
+			if !blank(line) {
+				filled = append(filled, line)
+			}
+		}
+
+		sb := strings.Builder{}
+		sb.WriteString(lines[0])
+		if len(lines) == len(filled)+1 {
+			// a line keeps the prefix of its source line
+			next := 1
+			for line := startLine + 1; line <= endLine; line++ {
+				if blank(line) {
+					sb.WriteString("\n" + rawLine(line))
+				} else {
+					sb.WriteString("\n" + prefix(line) + lines[next])
+					next++
+				}
+			}
+		} else if len(lines) > 1 {
+			// lines are added or removed, the inner lines take the prefix of the first inner source line, the blank
+			// lines around them are kept, and the last line keeps its own prefix
+			innerPrefix, lastPrefix := prefix(startLine), prefix(startLine)
+			innerFirst, innerLast := startLine+1, endLine-1
+			if len(filled) > 0 {
+				lastPrefix = prefix(endLine)
+			}
+			if len(filled) > 1 {
+				innerPrefix = prefix(filled[0])
+				innerFirst, innerLast = filled[0], filled[len(filled)-2]
+			}
+
+			for line := startLine + 1; line < innerFirst; line++ {
+				sb.WriteString("\n" + rawLine(line))
+			}
+			for _, l := range lines[1 : len(lines)-1] {
+				sb.WriteString("\n" + innerPrefix + l)
+			}
+			for line := innerLast + 1; line < endLine && len(filled) > 1; line++ {
+				sb.WriteString("\n" + rawLine(line))
+			}
+			sb.WriteString("\n" + lastPrefix + lines[len(lines)-1])
+		}
+
+		from, to := offset(startLine, a.Name.Pos.Column-2), offset(endLine, a.endColumn())
+		result = result[:from] + sb.String() + result[to:]
+	}
+
+	return result, nil
+}
+
+// written reports whether the annotation is written in the text as an "@" right before its name, at the start of a
+// line or after a space.
+func (a *Annotation) written(textLines []string) bool {
+	runes := []rune(textLines[a.Name.Pos.Line-1])
+	at := a.Name.Pos.Column - 2
+	return at >= 0 && at < len(runes) && runes[at] == '@' && (at == 0 || unicode.IsSpace(runes[at-1]))
+}
+
+// inOneBlock reports whether the lines are not part of a block comment and a directive, unless they are all in the
+// same block comment, the text of such an annotation can not be rewritten.
+func inOneBlock(fileSet *token.FileSet, cg *goast.CommentGroup, startLine, endLine int) bool {
+	var comments []*goast.Comment
+	block := false
+	for _, c := range cg.List {
+		if fileSet.Position(c.End()).Line < startLine || fileSet.Position(c.Pos()).Line > endLine {
+			continue
+		}
+		comments = append(comments, c)
+		block = block || strings.HasPrefix(c.Text, "/*") || isDirective(c.Text)
+	}
+	return !block || len(comments) == 1
+}
+
+// startsWithAnnotation reports whether a line of the text starts with an annotation like @Name.
+func startsWithAnnotation(textLines []string) bool {
+	for _, line := range textLines {
+		runes := []rune(strings.TrimLeftFunc(line, unicode.IsSpace))
+		if len(runes) > 1 && runes[0] == '@' && (unicode.IsLetter(runes[1]) || runes[1] == '_') {
+			return true
+		}
+	}
+	return false
+}
+
+// endLine returns the last source line of the annotation, without the comment after it.
+func (a *Annotation) endLine() int {
+	line := a.Name.Pos.Line
+	if a.Params != nil && a.Params.ClosedParenthesis.Pos.Line > line {
+		line = a.Params.ClosedParenthesis.Pos.Line
+	}
+	if a.Extends != nil && a.Extends.ClosedBracket.Pos.Line > line {
+		line = a.Extends.ClosedBracket.Pos.Line
+	}
+	return line
+}
+
+// endColumn returns the column following the annotation in its last line, without the comment after it.
+func (a *Annotation) endColumn() int {
+	switch {
+	case a.Extends != nil:
+		return a.Extends.ClosedBracket.Pos.Column
+	case a.Params != nil:
+		return a.Params.ClosedParenthesis.Pos.Column
+	default:
+		return a.Name.Pos.Column - 1 + utf8.RuneCountInString(a.Name.Text)
+	}
+}
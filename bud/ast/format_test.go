@@ -0,0 +1,175 @@
+package ast
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestAnnotationFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"params", `@EnumConfig( marshal,prefix = "Acme" ,size=0x10, code int,list=[a,"b c"],m={k:1,"x y":true},inner=@Inner( a=1 ))`,
+			[]string{`@EnumConfig(marshal, prefix="Acme", size=0x10, code int, list=[a, "b c"], m={k: 1, "x y": true}, inner=@Inner(a=1))`}},
+		{"inline", `@ENUM{a,_,b=20,c}`, []string{`@ENUM{a, _, b = 20, c}`}},
+		{"table", "@ENUM (Name string, Code int){\nred(_,1) // red\n  green_light(\"green light\",22)=5\nblue(_,333),\n}",
+			[]string{
+				"@ENUM(Name string, Code int) {",
+				`red        (_,             1) // red`,
+				`green_light("green light", 22) = 5`,
+				`blue       (_,             333)`,
+				"}",
+			}},
+		{"comments", "@Tag(\n// before a\na, // after a\nb\n) // tag",
+			[]string{"@Tag(", "// before a", "a, // after a", "b,", ") // tag"}},
+		{"transitions", "@ENUM{\npending -> inWork, rejected\ninWork(1) -> [completed]\ncompleted(2)\nrejected(3) = 9 -> pending // back\n}",
+			[]string{"@ENUM{", "pending         -> inWork, rejected", "inWork(1)       -> completed", "completed(2)", "rejected(3) = 9 -> pending // back", "}"}},
+		{"inline transitions", "@ENUM{a->[b,c], b, c}", []string{"@ENUM{a -> [b, c], b, c}"}},
+		{"moved comment", "@ENUM{\na\n// before b\nb = 2 // b\nccc = 3\n}",
+			[]string{"@ENUM{", "a", "// before b", "b   = 2 // b", "ccc = 3", "}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ag, err := ParseAnnotation("a.go", tt.text)
+			assert.NoError(t, err)
+			assert.Len(t, ag.Annotations, 1)
+			lines := ag.Annotations[0].Format()
+			assert.Equal(t, tt.want, lines)
+
+			// the formatted annotation is parsed to the same annotation, and formatted the same again
+			formatted, err := ParseAnnotation("a.go", strings.Join(lines, "\n"))
+			assert.NoError(t, err)
+			assert.Empty(t, cmp.Diff(ag, formatted, cmp.FilterPath(ignorePosFields, cmp.Ignore())))
+			assert.Equal(t, lines, formatted.Annotations[0].Format())
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	for _, tt := range []struct {
+		value Value
+		want  string
+	}{
+		{String{V: "red"}, "red"},
+		{String{V: "time.Second"}, "time.Second"},
+		{String{V: "true"}, `"true"`},
+		{String{V: "1"}, `"1"`},
+		{String{V: "a b"}, `"a b"`},
+		{Int{V: -5}, "-5"},
+		{Float{V: 2}, "2.0"},
+		{Bool{V: true}, "true"},
+		{NestedAnnotation{Name: Name{Text: "Label"}}, "@Label"},
+	} {
+		assert.Equal(t, tt.want, FormatValue(tt.value))
+	}
+	assert.Equal(t, `[a, 1, {"x y": "1"}]`, FormatValue(List{V: []Value{String{V: "a"}, Int{V: 1}, Map{Entries: []*MapEntry{{Key: "x y", Value: String{V: "1"}}}}}}))
+}
+
+func TestFormatCommentGroup(t *testing.T) {
+	src := `package color
+
+// Color is a color.
+//@EnumConfig(marshal,ptr) @Other
+/* @ENUM (Name string){
+Black(_), White(_)
+Green(_) = 33 // Green starts with 33
+*/
+// grey(_)=45
+// }.
+type Color int
+
+// Size @ENUM{small,large}, see the docs
+type Size int
+
+	/*
+	  @ENUM(Code int){
+
+	    s(1), m(2)
+	  l(3) // large
+	  } the sizes
+	*/
+//	@Tag(a,
+//		b)
+type Indented int
+`
+	fileSet := token.NewFileSet()
+	fileNode, err := goparser.ParseFile(fileSet, "color.go", src, goparser.ParseComments)
+	assert.NoError(t, err)
+
+	// the @ENUM spanning a block comment and line comments is left as it is
+	text, err := FormatCommentGroup(fileSet, fileNode.Comments[0], []byte(src))
+	assert.NoError(t, err)
+	assert.Equal(t, `// Color is a color.
+//@EnumConfig(marshal, ptr) @Other
+/* @ENUM (Name string){
+Black(_), White(_)
+Green(_) = 33 // Green starts with 33
+*/
+// grey(_)=45
+// }.`, text)
+
+	text, err = FormatCommentGroup(fileSet, fileNode.Comments[1], []byte(src))
+	assert.NoError(t, err)
+	assert.Equal(t, "// Size @ENUM{small, large}, see the docs", text)
+
+	// the added lines take the indentation of the inner lines, the blank lines and the closing bracket are kept
+	text, err = FormatCommentGroup(fileSet, fileNode.Comments[2], []byte(src))
+	assert.NoError(t, err)
+	assert.Equal(t, `/*
+	  @ENUM(Code int) {
+
+	    s(1)
+	    m(2)
+	    l(3) // large
+	  } the sizes
+	*/
+//	@Tag(a, b)`, text)
+
+	_, err = FormatCommentGroup(fileSet, &goast.CommentGroup{List: []*goast.Comment{{Slash: fileNode.Comments[1].Pos(), Text: "// @Broken(a=[1)"}}}, []byte(src))
+	assert.Error(t, err)
+}
+
+func TestFormatCommentGroupItems(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"trailing commas", "// Animal @ENUM(Name string){\n// Cat(_),\n// Dog(Dog),\n// Fish(\"Fish\")\n// }",
+			"// Animal @ENUM(Name string) {\n// Cat(_)\n// Dog(Dog)\n// Fish(\"Fish\")\n// }"},
+		{"tab aligned values", "/*\n@EnumConfig(values)\n@ENUM{\n\nUnkno\t\t\t\t\t= 0\nE2P15\t\t\t\t\t= 32768\n\n}\n*/",
+			"/*\n@EnumConfig(values)\n@ENUM{\n\nUnkno = 0\nE2P15 = 32768\n\n}\n*/"},
+		{"comment with a comma", "/*\n@ENUM{\n\t_, // Placeholder with a ','  in it.\nvalue1 // Commented value 1\nvalue2,\n_\n}\n*/",
+			"/*\n@ENUM{\n\t_      // Placeholder with a ','  in it.\nvalue1 // Commented value 1\nvalue2\n_\n}\n*/"},
+		{"negative values", "/*\n@ENUM{\nUnknown = -5,\nGood,\nBad\n}.\n*/",
+			"/*\n@ENUM{\nUnknown = -5\nGood\nBad\n}.\n*/"},
+		{"inline items", "// Make x @ENUM{Toyota,_,Chevy,_,Ford}\n// NoZeros x @ENUM{start=20,middle,end}",
+			"// Make x @ENUM{Toyota, _, Chevy, _, Ford}\n// NoZeros x @ENUM{start = 20, middle, end}"},
+		{"attribute columns", "// @ENUM(Name string, Weight int, Urgent bool){\n// low(_, 1, false)   // can wait\n// // handled in the sprint\n// medium(_, 5, false)\n// high(_, 10, true)  // fixed immediately\n// }",
+			"// @ENUM(Name string, Weight int, Urgent bool) {\n// low   (_, 1,  false) // can wait\n// // handled in the sprint\n// medium(_, 5,  false)\n// high  (_, 10, true)  // fixed immediately\n// }"},
+		{"formatted", "// @EnumConfig(marshal)\n// @ENUM{\n// a\n// b = 3\n// }",
+			"// @EnumConfig(marshal)\n// @ENUM{\n// a\n// b = 3\n// }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package a\n\n" + tt.src + "\ntype A int\n"
+			fileSet := token.NewFileSet()
+			fileNode, err := goparser.ParseFile(fileSet, "a.go", src, goparser.ParseComments)
+			assert.NoError(t, err)
+			assert.Len(t, fileNode.Comments, 1)
+
+			text, err := FormatCommentGroup(fileSet, fileNode.Comments[0], []byte(src))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, text)
+		})
+	}
+}
@@ -2,10 +2,14 @@ package ast
 
 import (
 	"io"
+	"sort"
+	"strings"
+	"sync"
 	"text/template"
 )
 
 type Generator interface {
+	// GetImports returns the import paths of the generated code, an import path renamed in the code is `name path`.
 	GetImports() []string
 	WriteConst(wr io.Writer) error
 	WriteInitFunc(wr io.Writer) error
@@ -13,6 +17,78 @@ type Generator interface {
 	ExecuteTemplate(wr io.Writer, name string) error
 }
 
+// ExtraFileGenerator is implemented by a Generator which also writes a file in another language next to the go file,
+// like the TypeScript file of the enums. An extra file is named like the go file with the extension of its language,
+// and starts with the generated header of the go file, so the language must have // comments.
+type ExtraFileGenerator interface {
+	// ExtraFileExts returns the extensions of the extra files like ".ts", it is empty if there is nothing to write.
+	ExtraFileExts() []string
+	// WriteExtraFile writes the content of the extra file with extension ext.
+	WriteExtraFile(wr io.Writer, ext string) error
+}
+
+// NewGeneratorFunc creates the Generator of a source file, it returns a nil Generator if the file has nothing to generate.
+// If the returned error is Diagnostics only holding warnings, the Generator is still used.
+type NewGeneratorFunc func(file *File) (Generator, error)
+
+type registeredGenerator struct {
+	name    string
+	newFunc NewGeneratorFunc
+}
+
+var (
+	generatorsLock sync.RWMutex
+	generators     []*registeredGenerator
+)
+
+// RegisterGenerator registers a generator under name, it is usually called in the init function of the generator package.
+// Registering a name twice replaces the previous generator.
+func RegisterGenerator(name string, newFunc NewGeneratorFunc) {
+	if newFunc == nil {
+		panic("ast: RegisterGenerator newFunc is nil")
+	}
+
+	generatorsLock.Lock()
+	defer generatorsLock.Unlock()
+
+	for _, rg := range generators {
+		if rg.name == name {
+			rg.newFunc = newFunc
+			return
+		}
+	}
+
+	generators = append(generators, &registeredGenerator{name: name, newFunc: newFunc})
+	sort.Slice(generators, func(i, j int) bool { return generators[i].name < generators[j].name })
+}
+
+// GetGenerator returns the NewGeneratorFunc registered under name, or nil if not found.
+func GetGenerator(name string) NewGeneratorFunc {
+	generatorsLock.RLock()
+	defer generatorsLock.RUnlock()
+
+	for _, rg := range generators {
+		if rg.name == name {
+			return rg.newFunc
+		}
+	}
+
+	return nil
+}
+
+// GeneratorNames returns the names of all registered generators in sorted order.
+func GeneratorNames() []string {
+	generatorsLock.RLock()
+	defer generatorsLock.RUnlock()
+
+	names := make([]string, 0, len(generators))
+	for _, rg := range generators {
+		names = append(names, rg.name)
+	}
+
+	return names
+}
+
 type BaseGenerator[T any] struct {
 	Tmpl     *template.Template
 	DataList []*T
@@ -23,11 +99,29 @@ func (bg *BaseGenerator[T]) GetImports() []string {
 }
 
 func (bg *BaseGenerator[T]) ExecuteTemplate(wr io.Writer, name string) error {
-	tmpl := bg.Tmpl.Lookup(name)
+	for _, data := range bg.DataList {
+		if err := ExecuteSection(bg.Tmpl, wr, name, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExecuteSection executes the template name of tmpl, and then all the templates named "name.<block>" in sorted order.
+// So a template set can add extra blocks to a section like "body.audit" without replacing the section.
+func ExecuteSection(tmpl *template.Template, wr io.Writer, name string, data any) error {
+	var blocks []string
+	for _, t := range tmpl.Templates() {
+		if strings.HasPrefix(t.Name(), name+".") {
+			blocks = append(blocks, t.Name())
+		}
+	}
+	sort.Strings(blocks)
 
-	if tmpl != nil {
-		for _, data := range bg.DataList {
-			if err := tmpl.Execute(wr, data); err != nil {
+	for _, n := range append([]string{name}, blocks...) {
+		if t := tmpl.Lookup(n); t != nil {
+			if err := t.Execute(wr, data); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,21 @@
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRegisterGenerator(t *testing.T) {
+	newFunc := func(file *File) (Generator, error) {
+		return nil, nil
+	}
+
+	RegisterGenerator("test_b", newFunc)
+	RegisterGenerator("test_a", newFunc)
+	RegisterGenerator("test_b", newFunc)
+
+	assert.Equal(t, []string{"test_a", "test_b"}, GeneratorNames())
+	assert.NotNil(t, GetGenerator("test_a"))
+	assert.Nil(t, GetGenerator("test_c"))
+	assert.Panics(t, func() { RegisterGenerator("test_c", nil) })
+}
@@ -0,0 +1,229 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MetaAnnotation is a composite annotation expanding to other annotations, like @DbEnum expanding to
+// @EnumConfig(sql, marshal, ptr, nocomments).
+type MetaAnnotation struct {
+	Name        string
+	Annotations []*Annotation
+}
+
+// NewMetaAnnotation returns the meta-annotation name expanding to the annotations of text. fileName and the
+// positions of text are used to report the errors of the expanded annotations.
+func NewMetaAnnotation(name string, fileName string, text string) (*MetaAnnotation, error) {
+	ag, err := ParseAnnotation(fileName, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ag.Annotations) == 0 {
+		return nil, fmt.Errorf("meta-annotation %s expands to no annotation", name)
+	}
+
+	return &MetaAnnotation{Name: name, Annotations: ag.Annotations}, nil
+}
+
+var (
+	metaAnnotationsLock sync.RWMutex
+	metaAnnotations     = map[string]*MetaAnnotation{}
+)
+
+// RegisterMetaAnnotation registers a meta-annotation expanded in every file, the names are case-insensitive.
+// Registering a name twice replaces the previous meta-annotation.
+func RegisterMetaAnnotation(meta *MetaAnnotation) {
+	metaAnnotationsLock.Lock()
+	defer metaAnnotationsLock.Unlock()
+
+	metaAnnotations[strings.ToLower(meta.Name)] = meta
+}
+
+// GetMetaAnnotation returns the registered meta-annotation name, or nil if not found.
+func GetMetaAnnotation(name string) *MetaAnnotation {
+	metaAnnotationsLock.RLock()
+	defer metaAnnotationsLock.RUnlock()
+
+	return metaAnnotations[strings.ToLower(name)]
+}
+
+// ExpandMetaAnnotations replaces the meta-annotations of the group by the annotations they expand to. metas override
+// the registered meta-annotations of the same name.
+//
+// An expanded annotation is merged with an annotation of the same name given in the group, the params given in the
+// group override the expanded ones. The params of a meta-annotation itself, like @DbEnum(sqlName=code), are set to
+// the expanded annotations declaring them by their schema.
+func (ag *AnnotationGroup) ExpandMetaAnnotations(metas ...*MetaAnnotation) error {
+	lookup := func(name string) *MetaAnnotation {
+		for _, meta := range metas {
+			if strings.EqualFold(meta.Name, name) {
+				return meta
+			}
+		}
+		return GetMetaAnnotation(name)
+	}
+
+	var diagnostics Diagnostics
+	var result []*Annotation
+	// the annotations of result by lower case name, and whether they are expanded
+	byName := map[string]*Annotation{}
+	expanded := map[*Annotation]bool{}
+
+	add := func(a *Annotation, isExpanded bool) {
+		key := strings.ToLower(a.Name.Text)
+		prev, ok := byName[key]
+		if !ok || (!expanded[prev] && !isExpanded) {
+			result = append(result, a)
+			byName[key] = a
+			expanded[a] = isExpanded
+			return
+		}
+
+		// an expanded annotation is overridden by the annotation given in the group, or by a later expanded one
+		var merged *Annotation
+		if expanded[prev] {
+			merged = mergeAnnotations(prev, a)
+		} else {
+			merged = mergeAnnotations(a, prev)
+		}
+		for i := range result {
+			if result[i] == prev {
+				result[i] = merged
+			}
+		}
+		byName[key] = merged
+		expanded[merged] = expanded[prev] && isExpanded
+	}
+
+	for _, a := range ag.Annotations {
+		if lookup(a.Name.Text) == nil {
+			add(a, false)
+			continue
+		}
+
+		annotations, err := expandMetaAnnotation(a, lookup, nil)
+		if err != nil {
+			diagnostics.Append(err, LexerPosition(a.Name.Pos))
+			continue
+		}
+		for _, e := range annotations {
+			add(e, true)
+		}
+	}
+
+	ag.Annotations = result
+	return diagnostics.Err()
+}
+
+// expandMetaAnnotation returns the annotations the meta-annotation a expands to, stack holds the names of the
+// meta-annotations being expanded to detect cycles.
+func expandMetaAnnotation(a *Annotation, lookup func(string) *MetaAnnotation, stack []string) ([]*Annotation, error) {
+	meta := lookup(a.Name.Text)
+	for _, name := range stack {
+		if strings.EqualFold(name, meta.Name) {
+			return nil, Errorf(LexerPosition(a.Name.Pos), "meta-annotation @%s expands to itself: @%s", meta.Name, strings.Join(append(stack, meta.Name), " -> @"))
+		}
+	}
+	stack = append(stack, meta.Name)
+
+	var result []*Annotation
+	for _, ma := range meta.Annotations {
+		e := &Annotation{Comments: a.Comments, Name: ma.Name, Params: &Params{}, Extends: ma.Extends, Comment: a.Comment}
+		if ma.Params != nil {
+			e.Params.List = append(e.Params.List, ma.Params.List...)
+		}
+
+		if len(e.Name.Pos.Filename) == 0 {
+			// a meta-annotation declared in go code is reported at the position it is used
+			e.Name.Pos = a.Name.Pos
+			for i, p := range e.Params.List {
+				moved := *p
+				moved.Key.Pos = a.Name.Pos
+				e.Params.List[i] = &moved
+			}
+		}
+
+		if lookup(e.Name.Text) == nil {
+			result = append(result, e)
+			continue
+		}
+
+		nested, err := expandMetaAnnotation(e, lookup, stack)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, nested...)
+	}
+
+	if a.Params == nil {
+		return result, nil
+	}
+
+	var diagnostics Diagnostics
+	for _, p := range a.Params.List {
+		found := false
+		for _, e := range result {
+			if schema := GetSchema(e.Name.Text); hasParam(e, p.Key.Text) || (schema != nil && schema.Param(p.Key.Text) != nil) {
+				setParam(e, p)
+				found = true
+			}
+		}
+		if !found {
+			diagnostics.Add(Errorf(LexerPosition(p.Key.Pos), "unknown param %s of @%s, none of its annotations declares it", p.Key.Text, a.Name.Text))
+		}
+	}
+
+	return result, diagnostics.Err()
+}
+
+// mergeAnnotations returns override with the params of base it does not give.
+func mergeAnnotations(base *Annotation, override *Annotation) *Annotation {
+	merged := *override
+	merged.Params = &Params{}
+	if override.Params != nil {
+		merged.Params.ClosedParenthesis = override.Params.ClosedParenthesis
+	}
+
+	if base.Params != nil {
+		for _, p := range base.Params.List {
+			if !hasParam(override, p.Key.Text) {
+				merged.Params.List = append(merged.Params.List, p)
+			}
+		}
+	}
+	if override.Params != nil {
+		merged.Params.List = append(merged.Params.List, override.Params.List...)
+	}
+	if merged.Extends == nil {
+		merged.Extends = base.Extends
+	}
+
+	return &merged
+}
+
+// setParam sets p to the params of a, replacing the param of the same key.
+func setParam(a *Annotation, p *AnnotationParam) {
+	var params []*AnnotationParam
+	for _, ap := range a.Params.List {
+		if !strings.EqualFold(ap.Key.Text, p.Key.Text) {
+			params = append(params, ap)
+		}
+	}
+	a.Params.List = append(params, p)
+}
+
+func hasParam(a *Annotation, key string) bool {
+	if a.Params == nil {
+		return false
+	}
+
+	for _, p := range a.Params.List {
+		if strings.EqualFold(p.Key.Text, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type metaConfig struct {
+	Sql     bool
+	Marshal bool
+	Ptr     bool
+	SqlName string
+}
+
+func TestExpandMetaAnnotations(t *testing.T) {
+	RegisterSchema(NewSchema[metaConfig]("MetaConfig"))
+
+	dbEnum, err := NewMetaAnnotation("DbEnum", "", "@MetaConfig(sql, marshal, ptr)")
+	assert.NoError(t, err)
+	RegisterMetaAnnotation(dbEnum)
+
+	expand := func(text string, metas ...*MetaAnnotation) (*AnnotationGroup, error) {
+		ag, err := ParseAnnotation("a.go", text)
+		assert.NoError(t, err)
+		return ag, ag.ExpandMetaAnnotations(metas...)
+	}
+
+	ag, err := expand("@DbEnum @ENUM{a, b}")
+	assert.NoError(t, err)
+	assert.Len(t, ag.Annotations, 2)
+	c, err := AnnotationParamsTo[metaConfig](nil, ag.FindAnnotationByName("MetaConfig"))
+	assert.NoError(t, err)
+	assert.Equal(t, &metaConfig{Sql: true, Marshal: true, Ptr: true}, c)
+	assert.Equal(t, 2, ag.Annotations[0].Name.Pos.Column)
+
+	// the params given in the group override the expanded ones, before or after the meta-annotation
+	for _, text := range []string{"@MetaConfig(ptr=false, sqlName=code) @DbEnum", "@DbEnum @MetaConfig(ptr=false, sqlName=code)", "@DbEnum(ptr=false, sqlName=code)"} {
+		ag, err = expand(text)
+		assert.NoError(t, err)
+		assert.Len(t, ag.Annotations, 1)
+		c, err = AnnotationParamsTo[metaConfig](nil, ag.Annotations[0])
+		assert.NoError(t, err, text)
+		assert.Equal(t, &metaConfig{Sql: true, Marshal: true, SqlName: "code"}, c, text)
+	}
+
+	// a meta-annotation can expand to other meta-annotations, and the given ones override the registered ones
+	strEnum, err := NewMetaAnnotation("StrEnum", "bud.yaml", "@DbEnum(marshal=false) @Other")
+	assert.NoError(t, err)
+	ag, err = expand("@StrEnum", strEnum)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"MetaConfig", "Other"}, annotationNames(ag))
+	c, err = AnnotationParamsTo[metaConfig](nil, ag.Annotations[0])
+	assert.NoError(t, err)
+	assert.Equal(t, &metaConfig{Sql: true, Ptr: true}, c)
+
+	_, err = expand("@DbEnum(unknown)")
+	assert.EqualError(t, err, "a.go:1:9: error: unknown param unknown of @DbEnum, none of its annotations declares it")
+
+	loop, err := NewMetaAnnotation("Loop", "bud.yaml", "@Loop")
+	assert.NoError(t, err)
+	_, err = expand("@Loop", loop)
+	assert.EqualError(t, err, "bud.yaml:1:2: error: meta-annotation @Loop expands to itself: @Loop -> @Loop")
+}
+
+func annotationNames(ag *AnnotationGroup) []string {
+	var names []string
+	for _, a := range ag.Annotations {
+		names = append(names, a.Name.Text)
+	}
+	return names
+}
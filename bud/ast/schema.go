@@ -0,0 +1,278 @@
+package ast
+
+import (
+	"fmt"
+	"github.com/peace0phmind/bud/structure"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// AnnotationTag is the struct tag declaring the param of a field decoded by AnnotationParamsTo, like
+//
+//	MustParse bool `annotation:"mustParse,alias=must,exclusive=parse"`
+//
+// The first value is the param name, the field name by default, "-" skips the field. A param of a struct field is
+// given as a map, a nested annotation, or by dotted keys like db.name=x. The options are:
+//   - required: the param must be given
+//   - alias=a|b: other names of the param
+//   - exclusive=group: params of the same group cannot be used together
+const AnnotationTag = "annotation"
+
+// ParamSchema declares a param of an annotation.
+type ParamSchema struct {
+	Name string
+	// Kind is the kind the value must be converted to, reflect.Invalid accepts any value.
+	// A bool param can be given without value, which means true. The values of lists, maps and nested annotations
+	// are checked when they are decoded.
+	Kind reflect.Kind
+	// Type is the type of the field the param is decoded into, nil if unknown.
+	Type      reflect.Type
+	Required  bool
+	Aliases   []string
+	Exclusive string
+}
+
+// Schema declares the params of an annotation.
+type Schema struct {
+	Name   string
+	Params []*ParamSchema
+	// AllowUnknown accepts params not declared in Params, like the attributes of @ENUM.
+	AllowUnknown bool
+}
+
+var (
+	schemasLock sync.RWMutex
+	schemas     = map[string]*Schema{}
+)
+
+// RegisterSchema registers the schema of the annotation schema.Name, the annotation names are case-insensitive.
+// Registering a name twice replaces the previous schema.
+func RegisterSchema(schema *Schema) {
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+
+	schemas[strings.ToLower(schema.Name)] = schema
+}
+
+// GetSchema returns the schema of the annotation name, or nil if not found.
+func GetSchema(name string) *Schema {
+	schemasLock.RLock()
+	defer schemasLock.RUnlock()
+
+	return schemas[strings.ToLower(name)]
+}
+
+// NewSchema returns the schema of the annotation name decoded into T by AnnotationParamsTo, every exported field of
+// T is a param declared by its AnnotationTag.
+func NewSchema[T any](name string) *Schema {
+	return newSchema(reflect.TypeOf((*T)(nil)).Elem(), name)
+}
+
+func newSchema(structType reflect.Type, name string) *Schema {
+	schema := &Schema{Name: name}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			schema.Params = append(schema.Params, newSchema(field.Type, name).Params...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if ps := newParamSchema(field); ps != nil {
+			schema.Params = append(schema.Params, ps)
+		}
+	}
+
+	return schema
+}
+
+// newParamSchema returns the param declared by the AnnotationTag of the field, or nil if the field is skipped.
+func newParamSchema(field reflect.StructField) *ParamSchema {
+	tag := strings.Split(field.Tag.Get(AnnotationTag), ",")
+	if tag[0] == "-" {
+		return nil
+	}
+
+	ps := &ParamSchema{Name: tag[0], Kind: field.Type.Kind(), Type: field.Type}
+	if len(ps.Name) == 0 {
+		ps.Name = field.Name
+	}
+
+	for _, option := range tag[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "required":
+			ps.Required = true
+		case "alias":
+			ps.Aliases = strings.Split(value, "|")
+		case "exclusive":
+			ps.Exclusive = value
+		}
+	}
+
+	return ps
+}
+
+// Is reports whether key names the param, by its name or an alias.
+func (ps *ParamSchema) Is(key string) bool {
+	if strings.EqualFold(ps.Name, key) {
+		return true
+	}
+
+	for _, alias := range ps.Aliases {
+		if strings.EqualFold(alias, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check reports whether value can be decoded into a scalar param.
+func (ps *ParamSchema) check(value Value) error {
+	if ps.Type == nil {
+		_, err := structure.ConvertToKind(value.Value(), ps.Kind)
+		return err
+	}
+
+	return decodeValue(reflect.New(ps.Type).Elem(), ps.Name, value)
+}
+
+func (ps *ParamSchema) typeName() string {
+	if ps.Type == nil {
+		return ps.Kind.String()
+	}
+	return ps.Type.String()
+}
+
+// Param returns the param named key, or nil if not found.
+func (s *Schema) Param(key string) *ParamSchema {
+	for _, ps := range s.Params {
+		if ps.Is(key) {
+			return ps
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the params of a against the schema, every violation is reported at the position of its param.
+func (s *Schema) Validate(a *Annotation) error {
+	var diagnostics Diagnostics
+
+	given := map[*ParamSchema]*AnnotationParam{}
+	exclusive := map[string]*AnnotationParam{}
+	nested := map[*ParamSchema]bool{}
+
+	var params []*AnnotationParam
+	if a.Params != nil {
+		params = a.Params.List
+	}
+
+	for _, p := range params {
+		pos := LexerPosition(p.Key.Pos)
+
+		head, rest, dotted := strings.Cut(p.Key.Text, ".")
+		ps := s.Param(head)
+		if ps == nil {
+			if !s.AllowUnknown {
+				diagnostics.Add(Errorf(pos, "unknown param %s of @%s%s", head, a.Name.Text, s.suggest(head)))
+			}
+			continue
+		}
+
+		if dotted {
+			// the nested params are checked when they are decoded
+			if isScalarKind(ps.Kind) {
+				diagnostics.Add(Errorf(pos, "param %s of @%s is %s, it has no param %s", head, a.Name.Text, ps.typeName(), rest))
+			}
+			nested[ps] = true
+			continue
+		}
+
+		if prev, ok := given[ps]; ok {
+			diagnostics.Add(Errorf(pos, "param %s of @%s is already given as %s", p.Key.Text, a.Name.Text, prev.Key.Text))
+			continue
+		}
+		given[ps] = p
+
+		if len(ps.Exclusive) > 0 {
+			if other, ok := exclusive[ps.Exclusive]; ok {
+				diagnostics.Add(Errorf(pos, "param %s of @%s cannot be used together with %s", p.Key.Text, a.Name.Text, other.Key.Text))
+			} else {
+				exclusive[ps.Exclusive] = p
+			}
+		}
+
+		switch {
+		case p.Value == nil && ps.Kind != reflect.Bool && ps.Kind != reflect.Invalid:
+			diagnostics.Add(Errorf(pos, "param %s of @%s requires a %s value", p.Key.Text, a.Name.Text, ps.Kind))
+		case p.Value != nil && isScalarKind(ps.Kind):
+			if err := ps.check(p.Value); err != nil {
+				diagnostics.Add(Errorf(pos, "param %s of @%s must be %s, got %v", p.Key.Text, a.Name.Text, ps.typeName(), p.Value.Value()))
+			}
+		}
+	}
+
+	for _, ps := range s.Params {
+		if _, ok := given[ps]; ps.Required && !ok && !nested[ps] {
+			diagnostics.Add(Errorf(LexerPosition(a.Name.Pos), "@%s requires param %s", a.Name.Text, ps.Name))
+		}
+	}
+
+	return diagnostics.Err()
+}
+
+// suggest returns a hint naming the param closest to the unknown key, or an empty string if none is close.
+func (s *Schema) suggest(key string) string {
+	best, bestDistance := "", 3
+	for _, ps := range s.Params {
+		for _, name := range append([]string{ps.Name}, ps.Aliases...) {
+			if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDistance && d < len(key) {
+				best, bestDistance = ps.Name, d
+			}
+		}
+	}
+
+	if len(best) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %s?", best)
+}
+
+// editDistance returns the Levenshtein distance of a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type schemaConfig struct {
+	Marshal    bool
+	MustParse  bool   `annotation:",alias=must"`
+	Prefix     string `annotation:"prefix,required"`
+	ForceUpper bool   `annotation:",exclusive=case"`
+	ForceLower bool   `annotation:",exclusive=case"`
+	Size       int
+	Ignored    string `annotation:"-"`
+}
+
+func TestSchemaValidate(t *testing.T) {
+	RegisterSchema(NewSchema[schemaConfig]("SchemaConfig"))
+
+	parse := func(text string) *Annotation {
+		ag, err := ParseAnnotation("a.go", text)
+		assert.NoError(t, err)
+		return ag.Annotations[0]
+	}
+
+	c, err := AnnotationParamsTo[schemaConfig](nil, parse("@SchemaConfig(marshal, must, prefix=X, size=3)"))
+	assert.NoError(t, err)
+	assert.Equal(t, &schemaConfig{Marshal: true, MustParse: true, Prefix: "X", Size: 3}, c)
+
+	_, err = AnnotationParamsTo[schemaConfig](nil, parse("@SchemaConfig(mustpase, prefix=X)"))
+	assert.EqualError(t, err, "a.go:1:15: error: unknown param mustpase of @SchemaConfig, did you mean MustParse?")
+
+	_, err = AnnotationParamsTo[schemaConfig](nil, parse("@SchemaConfig(marshal)"))
+	assert.EqualError(t, err, "a.go:1:2: error: @SchemaConfig requires param prefix")
+
+	_, err = AnnotationParamsTo[schemaConfig](nil, parse("@SchemaConfig(prefix=X, forceUpper, forceLower)"))
+	assert.EqualError(t, err, "a.go:1:37: error: param forceLower of @SchemaConfig cannot be used together with forceUpper")
+
+	_, err = AnnotationParamsTo[schemaConfig](nil, parse("@SchemaConfig(prefix=X, size=abc, size, ignored=1)"))
+	assert.EqualError(t, err, "a.go:1:25: error: param size of @SchemaConfig must be int, got abc\n"+
+		"a.go:1:35: error: param size of @SchemaConfig is already given as size\n"+
+		"a.go:1:41: error: unknown param ignored of @SchemaConfig")
+
+	_, err = AnnotationParamsTo[schemaConfig](nil, parse("@SchemaConfig(prefix)"))
+	assert.EqualError(t, err, "a.go:1:15: error: param prefix of @SchemaConfig requires a string value")
+}
@@ -0,0 +1,79 @@
+package bud
+
+import (
+	"fmt"
+	"github.com/peace0phmind/bud/bud/ast"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Orphans returns the bud files of pkg which are not generated any more, because their source file was removed or
+// renamed, has no annotations left, or the output suffix has changed, and the extra files not generated any more.
+// outputs are all generated files of pkg, so Orphans must not be used for a package having errors.
+// The bud files of source files excluded by build constraints are never orphans, and a broken package has no orphans,
+// as the bud files of its unparsable files can not be told apart from orphans.
+func Orphans(pkg *Package, outputs []*Output, outputSuffix string) []string {
+	if pkg.IsBroken() {
+		return nil
+	}
+
+	outputSuffix = pkg.Config.Suffix(outputSuffix)
+	expected := map[string]bool{}
+	for _, output := range outputs {
+		expected[output.Path] = true
+	}
+
+	ignored := map[string]bool{}
+	for _, fileName := range pkg.IgnoredFileNames {
+		ignored[OutputFilePath(fileName, outputSuffix)] = true
+	}
+
+	var orphans []string
+	for _, fileName := range pkg.GeneratedFileNames {
+		if !expected[fileName] && !ignored[fileName] {
+			orphans = append(orphans, fileName)
+		}
+	}
+
+	// the extra files of the bud file of an ignored source file are kept with it
+	for _, fileName := range pkg.GeneratedExtraFileNames {
+		if !expected[fileName] && !ignored[strings.TrimSuffix(fileName, filepath.Ext(fileName))+".go"] {
+			orphans = append(orphans, fileName)
+		}
+	}
+
+	return orphans
+}
+
+// CleanPackages removes the orphaned bud files of every package matched by patterns, and writes the removed file
+// names to w. If dryRun is true, the orphaned bud files are only written to w.
+// Packages having errors are not cleaned.
+func CleanPackages(patterns []string, outputSuffix string, perPackage bool, dryRun bool, w io.Writer) (diagnostics ast.Diagnostics) {
+	fileSet := token.NewFileSet()
+
+	pkgs, err := LoadPackages(fileSet, patterns...)
+	diagnostics.Append(err, token.Position{})
+
+	for _, pkg := range pkgs {
+		outputs, pd := GenerateOutputs(pkg, fileSet, outputSuffix, perPackage)
+		diagnostics.Add(pd...)
+		if pd.HasErrors() {
+			continue
+		}
+
+		for _, orphan := range Orphans(pkg, outputs, outputSuffix) {
+			if !dryRun {
+				if err = os.Remove(orphan); err != nil {
+					diagnostics.Append(err, token.Position{Filename: orphan})
+					continue
+				}
+			}
+			_, _ = fmt.Fprintf(w, "remove %s\n", orphan)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,111 @@
+package bud
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanPackages(t *testing.T) {
+	dir := t.TempDir()
+	color := filepath.Join(dir, "color.go")
+	assert.NoError(t, os.WriteFile(color, []byte("package color\n\n// @ENUM{red, green}\ntype Color int\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "doc.go"), []byte("package color\n"), 0o644))
+
+	assert.Empty(t, GeneratePackages([]string{dir}, "_bud", false))
+	assert.FileExists(t, filepath.Join(dir, "color_bud.go"))
+
+	// rename the source file, the bud file of the old name is an orphan
+	assert.NoError(t, os.Rename(color, filepath.Join(dir, "colors.go")))
+	w := &bytes.Buffer{}
+	assert.Empty(t, CleanPackages([]string{dir}, "_bud", false, true, w))
+	assert.Equal(t, "remove "+filepath.Join(dir, "color_bud.go")+"\n", w.String())
+	assert.FileExists(t, filepath.Join(dir, "color_bud.go"))
+
+	// change the suffix, the bud files of the old suffix are orphans and removed by the generation
+	assert.Empty(t, GeneratePackages([]string{dir}, "_enum", false))
+	assert.NoFileExists(t, filepath.Join(dir, "color_bud.go"))
+	assert.FileExists(t, filepath.Join(dir, "colors_enum.go"))
+
+	// remove all annotations
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "colors.go"), []byte("package color\n\ntype Color int\n"), 0o644))
+	diagnostics := VerifyPackages([]string{dir}, "_enum", false, &bytes.Buffer{})
+	assert.EqualError(t, diagnostics, filepath.Join(dir, "colors_enum.go")+": error: orphaned bud file, its source no longer generates it, run bud clean")
+
+	w.Reset()
+	assert.Empty(t, CleanPackages([]string{dir}, "_enum", false, false, w))
+	assert.NoFileExists(t, filepath.Join(dir, "colors_enum.go"))
+}
+
+func TestGenerateFileRemovesOrphan(t *testing.T) {
+	dir := t.TempDir()
+	color := filepath.Join(dir, "color.go")
+	assert.NoError(t, os.WriteFile(color, []byte("package color\n\n// @ENUM{red, green}\ntype Color int\n"), 0o644))
+
+	assert.Empty(t, GenerateFile(color, "_bud"))
+	assert.FileExists(t, filepath.Join(dir, "color_bud.go"))
+
+	assert.NoError(t, os.WriteFile(color, []byte("package color\n\ntype Color int\n"), 0o644))
+	assert.Empty(t, GenerateFile(color, "_bud"))
+	assert.NoFileExists(t, filepath.Join(dir, "color_bud.go"))
+}
+
+func TestExtraFiles(t *testing.T) {
+	dir := t.TempDir()
+	color := filepath.Join(dir, "color.go")
+	colorTs := filepath.Join(dir, "color_bud.ts")
+	assert.NoError(t, os.WriteFile(color, []byte("package color\n\n// @EnumConfig(marshal, typescript)\n// @ENUM{red, green}\ntype Color int\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.ts"), []byte("export const notes = 1;\n"), 0o644))
+
+	assert.Empty(t, GeneratePackages([]string{dir}, "_bud", false))
+	content, err := os.ReadFile(colorTs)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `export type Color = "red" | "green";`)
+	assert.Empty(t, VerifyPackages([]string{dir}, "_bud", false, &bytes.Buffer{}))
+
+	// the enum is not exported any more, only the extra file of bud is an orphan
+	assert.NoError(t, os.WriteFile(color, []byte("package color\n\n// @ENUM{red, green}\ntype Color int\n"), 0o644))
+	w := &bytes.Buffer{}
+	assert.Empty(t, CleanPackages([]string{dir}, "_bud", false, false, w))
+	assert.Equal(t, "remove "+colorTs+"\n", w.String())
+	assert.FileExists(t, filepath.Join(dir, "notes.ts"))
+
+	// a single generated file writes and removes its extra files
+	assert.NoError(t, os.WriteFile(color, []byte("package color\n\n// @EnumConfig(typescript)\n// @ENUM{red, green}\ntype Color int\n"), 0o644))
+	assert.Empty(t, GenerateFile(color, "_bud"))
+	assert.FileExists(t, colorTs)
+	assert.NoError(t, os.WriteFile(color, []byte("package color\n\ntype Color int\n"), 0o644))
+	assert.Empty(t, GenerateFile(color, "_bud"))
+	assert.NoFileExists(t, colorTs)
+	assert.FileExists(t, filepath.Join(dir, "notes.ts"))
+}
+
+func TestBrokenPackageHasNoOrphans(t *testing.T) {
+	for _, perPackage := range []bool{false, true} {
+		dir := t.TempDir()
+		color := filepath.Join(dir, "color.go")
+		assert.NoError(t, os.WriteFile(color, []byte("package paint\n\n// @ENUM{red, green}\ntype Color int\n"), 0o644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "size.go"), []byte("package paint\n\n// @ENUM{small, large}\ntype Size int\n"), 0o644))
+
+		assert.Empty(t, GeneratePackages([]string{dir}, "_bud", perPackage))
+		budFiles, _ := filepath.Glob(filepath.Join(dir, "*_bud.go"))
+		assert.NotEmpty(t, budFiles)
+
+		// a syntax error while editing keeps the bud files of the package
+		assert.NoError(t, os.WriteFile(color, []byte("package paint\n\n// @ENUM{red, green}\ntype Color int {\n"), 0o644))
+
+		w := &bytes.Buffer{}
+		assert.True(t, CleanPackages([]string{dir}, "_bud", perPackage, false, w).HasErrors())
+		assert.Empty(t, w.String())
+
+		diagnostics := VerifyPackages([]string{dir}, "_bud", perPackage, w)
+		assert.NotContains(t, diagnostics.Error(), "orphaned")
+
+		assert.True(t, GeneratePackages([]string{dir}, "_bud", perPackage).HasErrors())
+		for _, budFile := range budFiles {
+			assert.FileExists(t, budFile)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package bud
+
+import (
+	"errors"
+	"fmt"
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/peace0phmind/bud/bud/ast"
+	"github.com/peace0phmind/bud/stream"
+	"go/token"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ConfigFileName is the name of the project configuration file, it is read from the module root.
+const ConfigFileName = "bud.yaml"
+
+// DefaultOutputSuffix is the file name suffix of the bud files if neither the flags nor the project configuration
+// set one.
+const DefaultOutputSuffix = "_bud"
+
+// Config is the project configuration read from the bud.yaml in the module root, like
+//
+//	outputSuffix: _gen
+//	generators: [enum]
+//	packages:
+//	  - pattern: ./...
+//	    annotations:
+//	      EnumConfig: {marshal: true, sql: true}
+//	  - pattern: ./model/...
+//	    annotations:
+//	      EnumConfig: {ptr: true}
+//	metaAnnotations:
+//	  DbEnum: "@EnumConfig(sql, marshal, ptr, nocomments)"
+type Config struct {
+	// OutputSuffix is the default file name suffix of the bud files, the -file-suffix flag overrides it.
+	OutputSuffix string `yaml:"outputSuffix"`
+	// Generators are the names of the enabled generators, all registered generators are enabled by default.
+	Generators []string `yaml:"generators"`
+	// Packages are the default annotations of the packages matched by a pattern.
+	Packages []*PackageConfig `yaml:"packages"`
+	// MetaAnnotations are the annotations expanded from a meta-annotation by its name, see ast.MetaAnnotation.
+	MetaAnnotations map[string]yaml.Node `yaml:"metaAnnotations"`
+
+	// Path is the path of the configuration file, empty if the module has no configuration file.
+	Path string `yaml:"-"`
+	// Root is the module root, the package patterns are relative to it.
+	Root string `yaml:"-"`
+
+	metas []*ast.MetaAnnotation
+}
+
+// PackageConfig sets the default annotations of the packages matched by Pattern.
+// The annotations of a source file override them, if several patterns match a package, the later ones win.
+type PackageConfig struct {
+	// Pattern is a package directory relative to the module root, a trailing /... includes all subdirectories.
+	Pattern string `yaml:"pattern"`
+	// Annotations are the default annotation params by annotation name.
+	Annotations map[string]yaml.Node `yaml:"annotations"`
+
+	annotations []*ast.Annotation
+}
+
+// LoadConfig reads the configuration of the module containing dir. A module without configuration file has an empty
+// configuration, a directory outside a module uses the configuration file in dir.
+func LoadConfig(dir string) (*Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{Root: moduleRoot(dir)}
+
+	configPath := filepath.Join(config.Root, ConfigFileName)
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+	config.Path = configPath
+
+	var diagnostics ast.Diagnostics
+	for _, name := range config.Generators {
+		if ast.GetGenerator(name) == nil {
+			diagnostics.Add(ast.Errorf(token.Position{Filename: configPath}, "unknown generator %s, the registered generators are %s", name, strings.Join(ast.GeneratorNames(), ", ")))
+		}
+	}
+
+	for _, pc := range config.Packages {
+		diagnostics.Add(pc.parseAnnotations(config)...)
+	}
+
+	diagnostics.Add(config.parseMetaAnnotations()...)
+
+	if diagnostics.HasErrors() {
+		return nil, diagnostics
+	}
+	return config, nil
+}
+
+// moduleRoot returns the nearest directory of dir containing a go.mod, or dir itself.
+func moduleRoot(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d
+		}
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// Suffix returns the bud file name suffix, outputSuffix set by the flags wins over the configuration.
+func (c *Config) Suffix(outputSuffix string) string {
+	if len(outputSuffix) > 0 {
+		return outputSuffix
+	}
+	if c != nil && len(c.OutputSuffix) > 0 {
+		return c.OutputSuffix
+	}
+	return DefaultOutputSuffix
+}
+
+// Enabled reports whether the generator name is enabled.
+func (c *Config) Enabled(name string) bool {
+	if c == nil || len(c.Generators) == 0 {
+		return true
+	}
+
+	for _, g := range c.Generators {
+		if g == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Defaults returns the default annotations of the package in dir, the params of all matching package patterns are
+// merged in order.
+func (c *Config) Defaults(dir string) (group ast.AnnotationGroup) {
+	if c == nil {
+		return
+	}
+
+	rel, err := filepath.Rel(c.Root, dir)
+	if err != nil {
+		return
+	}
+
+	merged := map[string]*ast.Annotation{}
+	for _, pc := range c.Packages {
+		if !matchPattern(pc.Pattern, filepath.ToSlash(rel)) {
+			continue
+		}
+
+		for _, a := range pc.annotations {
+			m, ok := merged[strings.ToLower(a.Name.Text)]
+			if !ok {
+				m = &ast.Annotation{Name: a.Name, Params: &ast.Params{}}
+				merged[strings.ToLower(a.Name.Text)] = m
+				group.Annotations = append(group.Annotations, m)
+			}
+
+			for _, p := range a.Params.List {
+				m.Params.List = stream.Must(stream.Of(m.Params.List).Filter(func(mp *ast.AnnotationParam) (bool, error) {
+					return !strings.EqualFold(mp.Key.Text, p.Key.Text), nil
+				}).ToSlice())
+				m.Params.List = append(m.Params.List, p)
+			}
+		}
+	}
+
+	return
+}
+
+// Metas returns the meta-annotations of the configuration sorted by name.
+func (c *Config) Metas() []*ast.MetaAnnotation {
+	if c == nil {
+		return nil
+	}
+	return c.metas
+}
+
+// parseMetaAnnotations parses the annotations of the meta-annotations, keeping their positions in the configuration file.
+func (c *Config) parseMetaAnnotations() (diagnostics ast.Diagnostics) {
+	var names []string
+	for name := range c.MetaAnnotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		node := c.MetaAnnotations[name]
+		if node.Kind != yaml.ScalarNode {
+			diagnostics.Add(ast.Errorf(c.position(&node), "meta-annotation %s must be a string of annotations", name))
+			continue
+		}
+
+		// pad the text, so the annotations are parsed at their line and column in the configuration file
+		column := node.Column
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			column++
+		}
+		text := strings.Repeat("\n", node.Line-1) + strings.Repeat(" ", column-1) + node.Value
+		if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			text = strings.Repeat("\n", node.Line) + node.Value
+		}
+
+		meta, err := ast.NewMetaAnnotation(name, c.Path, text)
+		if err != nil {
+			diagnostics.Append(err, c.position(&node))
+			continue
+		}
+		c.metas = append(c.metas, meta)
+	}
+
+	return
+}
+
+// parseAnnotations converts the annotations of the package configuration to ast annotations sorted by name.
+func (pc *PackageConfig) parseAnnotations(c *Config) (diagnostics ast.Diagnostics) {
+	var names []string
+	for name := range pc.Annotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		node := pc.Annotations[name]
+		if node.Kind != yaml.MappingNode {
+			diagnostics.Add(ast.Errorf(c.position(&node), "annotation %s must be a mapping of params", name))
+			continue
+		}
+
+		a := &ast.Annotation{
+			Name:   ast.Name{Pos: c.lexerPosition(&node), Text: name},
+			Params: &ast.Params{},
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, valueNode := node.Content[i], node.Content[i+1]
+
+			var v any
+			if err := valueNode.Decode(&v); err != nil {
+				diagnostics.Add(ast.Errorf(c.position(valueNode), "%s %s: %v", name, key.Value, err))
+				continue
+			}
+
+			value, err := ast.NewValue(v)
+			if err != nil {
+				diagnostics.Add(ast.Errorf(c.position(valueNode), "%s %s: %v", name, key.Value, err))
+				continue
+			}
+
+			a.Params.List = append(a.Params.List, &ast.AnnotationParam{
+				Key:   ast.Key{Pos: c.lexerPosition(key), Text: key.Value},
+				Value: value,
+			})
+		}
+
+		pc.annotations = append(pc.annotations, a)
+	}
+
+	return
+}
+
+func (c *Config) position(node *yaml.Node) token.Position {
+	return ast.LexerPosition(c.lexerPosition(node))
+}
+
+func (c *Config) lexerPosition(node *yaml.Node) lexer.Position {
+	return lexer.Position{Filename: c.Path, Line: node.Line, Column: node.Column}
+}
+
+// matchPattern reports whether the slash separated directory rel, relative to the module root, matches pattern.
+func matchPattern(pattern string, rel string) bool {
+	pattern = path.Clean(pattern)
+	if pattern == "..." {
+		return true
+	}
+
+	if prefix := strings.TrimSuffix(pattern, "/..."); prefix != pattern {
+		return rel == prefix || strings.HasPrefix(rel, prefix+"/")
+	}
+
+	return rel == pattern
+}
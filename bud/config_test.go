@@ -0,0 +1,146 @@
+package bud
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchPattern(t *testing.T) {
+	assert.True(t, matchPattern("./...", "."))
+	assert.True(t, matchPattern("./...", "model/user"))
+	assert.True(t, matchPattern(".", "."))
+	assert.False(t, matchPattern(".", "model"))
+	assert.True(t, matchPattern("./model/...", "model"))
+	assert.True(t, matchPattern("model/...", "model/user"))
+	assert.False(t, matchPattern("./model/...", "models"))
+	assert.True(t, matchPattern("./model", "model"))
+	assert.False(t, matchPattern("./model", "model/user"))
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestGeneratePackagesConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/config\n\ngo 1.20\n",
+		"bud.yaml": `outputSuffix: _gen
+generators: [enum]
+packages:
+  - pattern: ./...
+    annotations:
+      EnumConfig: {marshal: true, names: true}
+  - pattern: ./model/...
+    annotations:
+      EnumConfig: {names: false}
+`,
+		"color.go":       "package config\n\n// @ENUM{red, green}\ntype Color int\n",
+		"model/state.go": "package model\n\n// @ENUM{on, off}\ntype State int\n\n// @EnumConfig(marshal=false)\n// @ENUM{up, down}\ntype Direction int\n",
+	})
+
+	assert.Empty(t, GeneratePackages([]string{dir + "/..."}, "", false))
+
+	color, err := os.ReadFile(filepath.Join(dir, "color_gen.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(color), "func (x Color) MarshalText()")
+	assert.Contains(t, string(color), "func ColorNames()")
+
+	state, err := os.ReadFile(filepath.Join(dir, "model", "state_gen.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(state), "func (x State) MarshalText()")
+	assert.NotContains(t, string(state), "func StateNames()")
+	assert.NotContains(t, string(state), "func (x Direction) MarshalText()")
+
+	// the flag wins over the configuration
+	assert.Empty(t, GeneratePackages([]string{dir}, "_bud", false))
+	assert.FileExists(t, filepath.Join(dir, "color_bud.go"))
+	assert.NoFileExists(t, filepath.Join(dir, "color_gen.go"))
+
+	// disable the enum generator
+	writeFiles(t, dir, map[string]string{"bud.yaml": "generators: [singleton]\n"})
+	assert.Empty(t, GeneratePackages([]string{dir}, "", false))
+	assert.NoFileExists(t, filepath.Join(dir, "color_bud.go"))
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/config\n\ngo 1.20\n",
+		"bud.yaml": `generators: [enum, unknown]
+packages:
+  - pattern: ./...
+    annotations:
+      EnumConfig: {marshal: 2020-01-01}
+`,
+	})
+
+	_, err := LoadConfig(filepath.Join(dir, "model"))
+	assert.Error(t, err)
+	lines := strings.Split(err.Error(), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "bud.yaml: error: unknown generator unknown")
+	assert.Contains(t, lines[1], "bud.yaml:5:29: error: EnumConfig marshal: unsupported annotation value")
+}
+
+func TestGeneratePackagesConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":   "module example.com/config\n\ngo 1.20\n",
+		"color.go": "package config\n\n// @ENUM{red, green}\ntype Color int\n",
+		"size.go":  "package config\n\n// @ENUM{small, large}\ntype Size int\n",
+	})
+
+	assert.Empty(t, GeneratePackages([]string{dir}, "", false))
+	assert.FileExists(t, filepath.Join(dir, "size_bud.go"))
+
+	// with an invalid configuration, nothing is written or removed
+	assert.NoError(t, os.Remove(filepath.Join(dir, "size.go")))
+	writeFiles(t, dir, map[string]string{
+		"bud.yaml": "outputSuffix: [_gen\n",
+		"model.go": "package config\n\n// @ENUM{on, off}\ntype State int\n",
+	})
+
+	diagnostics := GeneratePackages([]string{dir}, "", false)
+	assert.Contains(t, diagnostics.Error(), "bud.yaml")
+	assert.FileExists(t, filepath.Join(dir, "size_bud.go"))
+	assert.NoFileExists(t, filepath.Join(dir, "model_bud.go"))
+
+	diagnostics = CleanPackages([]string{dir}, "", false, false, &bytes.Buffer{})
+	assert.Contains(t, diagnostics.Error(), "bud.yaml")
+	assert.FileExists(t, filepath.Join(dir, "size_bud.go"))
+}
+
+func TestGeneratePackagesMetaAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/meta\n\ngo 1.20\n",
+		"bud.yaml": `metaAnnotations:
+  DbEnum: "@EnumConfig(sql, marshal, ptr)"
+  BadEnum: "@EnumConfig(sql, mashal)"
+`,
+		"status.go": "package meta\n\n// @DbEnum\n// @ENUM{on, off}\ntype Status int\n\n// @DbEnum(marshal=false)\n// @ENUM{up, down}\ntype Direction int\n",
+	})
+
+	assert.Empty(t, GeneratePackages([]string{dir}, "", false))
+
+	status, err := os.ReadFile(filepath.Join(dir, "status_bud.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(status), "func (x Status) Value() (driver.Value, error)")
+	assert.Contains(t, string(status), "func (x Status) MarshalText()")
+	assert.Contains(t, string(status), "func (x Direction) Value() (driver.Value, error)")
+	assert.NotContains(t, string(status), "func (x Direction) MarshalText()")
+
+	// the errors of an expanded annotation are reported in the configuration file
+	writeFiles(t, dir, map[string]string{"status.go": "package meta\n\n// @BadEnum\n// @ENUM{on, off}\ntype Status int\n"})
+	diagnostics := GeneratePackages([]string{dir}, "", false)
+	assert.Contains(t, diagnostics.Error(), "bud.yaml:3:30: error: unknown param mashal of @EnumConfig, did you mean Marshal?")
+}
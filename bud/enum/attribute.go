@@ -3,20 +3,99 @@ package enum
 import (
 	"bytes"
 	"fmt"
+	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/peace0phmind/bud/structure"
+	"go/token"
+	"go/types"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
 type Attribute struct {
 	enum                   *Enum
+	pos                    lexer.Position
 	idx                    int
 	isValue                bool
 	enum2AttributeRendered bool
 	attribute2EnumRendered bool
 	Name                   string
-	Type                   reflect.Kind
+	Type                   reflect.Kind // the kind of the attribute, or the underlying kind of a named type
+	TypeName               string       // the attribute type used in the generated code
 	Comment                string
+	typ                    types.Type // the named type of the attribute, nil for builtin types
+	values                 map[*Item]string
+}
+
+// IsBuiltin reports whether the attribute has a builtin type, instead of a named type like time.Duration.
+func (ea *Attribute) IsBuiltin() bool {
+	return ea.typ == nil
+}
+
+// ZeroValue returns the go code of the zero value of the attribute type.
+func (ea *Attribute) ZeroValue() string {
+	if ea.typ != nil {
+		switch ea.typ.Underlying().(type) {
+		case *types.Basic:
+		case *types.Struct, *types.Array:
+			return ea.TypeName + "{}"
+		default:
+			return "nil"
+		}
+	}
+
+	switch ea.Type {
+	case reflect.String:
+		return "\"\""
+	case reflect.Bool:
+		return "false"
+	default:
+		return "0"
+	}
+}
+
+// renderValue renders the attribute data of item to the go code of a value of the named attribute type.
+// The data is evaluated as a go expression like time.Second or another enum item, a constant is used as a literal.
+func (ea *Attribute) renderValue(item *Item) error {
+	data := item.AttributeData[ea.idx]
+	code := ""
+
+	if expr, ok := data.(string); ok {
+		tv, imports, err := ea.enum.file.Eval(ea.enum.spec.Pos(), expr)
+		switch {
+		case err == nil && tv.IsValue():
+			if !types.AssignableTo(tv.Type, ea.typ) {
+				return fmt.Errorf("%s is %s", expr, tv.Type)
+			}
+			code = expr
+		case ea.Type == reflect.String:
+			code = strconv.Quote(expr)
+		case err != nil && token.IsIdentifier(strings.ReplaceAll(expr, ".", "")):
+			// the identifier may be declared by a bud file not generated yet, the compiler checks it
+			code = expr
+		case err != nil:
+			return err
+		default:
+			return fmt.Errorf("%s is not a value", expr)
+		}
+		ea.enum.addImports(imports...)
+	} else {
+		if ea.Type == reflect.Invalid {
+			return fmt.Errorf("%v is not an expression", data)
+		}
+
+		value, err := structure.ConvertToKind(data, ea.Type)
+		if err != nil {
+			return err
+		}
+		code = fmt.Sprintf("%v", value)
+	}
+
+	if ea.values == nil {
+		ea.values = map[*Item]string{}
+	}
+	ea.values[item] = code
+	return nil
 }
 
 func (ea *Attribute) Enum() *Enum {
@@ -49,7 +128,7 @@ func (ea *Attribute) Enum2AttributeMap() string {
 
 	buf := bytes.NewBuffer([]byte{})
 
-	buf.WriteString(fmt.Sprintf("var %s = map[%s]%s{\n", ea.Enum2AttributeVarName(), ea.enum.Name, ea.Type.String()))
+	buf.WriteString(fmt.Sprintf("var %s = map[%s]%s{\n", ea.Enum2AttributeVarName(), ea.enum.Name, ea.TypeName))
 	if ea.idx == 0 {
 		index := 0
 		for _, item := range ea.enum.GetItems() {
@@ -59,8 +138,12 @@ func (ea *Attribute) Enum2AttributeMap() string {
 		}
 	} else {
 		for _, item := range ea.enum.GetItems() {
-			switch ea.Type {
-			case reflect.String:
+			switch {
+			case !ea.IsBuiltin():
+				if code, ok := ea.values[item]; ok {
+					buf.WriteString(fmt.Sprintf("	%s: %s,\n", item.GetCodeName(), code))
+				}
+			case ea.Type == reflect.String:
 				buf.WriteString(fmt.Sprintf("	%s: \"%s\",\n", item.GetCodeName(), structure.MustConvertTo[string](item.AttributeData[ea.idx])))
 			default:
 				buf.WriteString(fmt.Sprintf("	%s: %v,\n", item.GetCodeName(), structure.MustConvertToKind(item.AttributeData[ea.idx], ea.Type)))
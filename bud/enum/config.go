@@ -4,47 +4,70 @@ import (
 	"fmt"
 	"github.com/peace0phmind/bud/stream"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 type Config struct {
 	enum            *Enum
 	Prefix          string
-	NoPrefix        bool   `value:"false"` // 所有生成的枚举不携带类型名称前缀
-	StringParse     bool   `value:"true"`
-	StringParseName string `value:"Name"`
-	Flag            bool   `value:"false"`
-	MustParse       bool   `value:"false"`
-	Marshal         bool   `value:"false"`
-	MarshalName     string `value:"Name"`
-	Sql             bool   `value:"false"`
-	SqlName         string `value:"Val"`
-	Names           bool   `value:"false"` // enum name list
-	Values          bool   `value:"false"` // enum item list
-	NoCase          bool   `value:"false"` // case insensitivity
-	NoCamel         bool   `value:"false"`
-	NoComments      bool   `value:"false"`
-	Ptr             bool   `value:"false"`
-	ForceUpper      bool   `value:"false"`
-	ForceLower      bool   `value:"false"`
-	PanicIfInvalid  bool   `value:"false"`
+	NoPrefix        bool              `value:"false"` // 所有生成的枚举不携带类型名称前缀
+	StringParse     bool              `value:"true"`
+	StringParseName string            `value:"Name"`
+	Flag            bool              `value:"false" annotation:",exclusive=set"`
+	Bitmask         bool              `value:"false" annotation:",exclusive=set"` // items are bit flags combined with |
+	MustParse       bool              `value:"false"`
+	Marshal         bool              `value:"false"`
+	MarshalName     string            `value:"Name"`
+	Sql             bool              `value:"false"`
+	SqlName         string            `value:"Val"`
+	Gorm            bool              `value:"false"` // gorm data types restricting the column to the stored values
+	Names           bool              `value:"false"` // enum name list
+	Values          bool              `value:"false"` // enum item list
+	Ordinal         bool              `value:"false"` // declaration order, navigation and comparison of items
+	JSONSchema      bool              `value:"false"` // JSON schema of the encoded values, registered in package jsonschema
+	TypeScript      bool              `value:"false"` // TypeScript constants in a .ts file next to the go file
+	Proto           string            // protobuf enum converted to and from, like pb.Status or example.com/api/pb.Status
+	ProtoName       string            // attribute holding the names of the protobuf values, the item names by default
+	Labels          map[string]string // label attributes by language, like {en: LabelEn, "zh-TW": LabelZhTw}
+	LabelLang       string            // language the labels of the other languages fall back to
+	NoCase          bool              `value:"false"` // case insensitivity
+	NoCamel         bool              `value:"false"`
+	NoComments      bool              `value:"false"`
+	Ptr             bool              `value:"false"`
+	ForceUpper      bool              `value:"false" annotation:",exclusive=case"`
+	ForceLower      bool              `value:"false" annotation:",exclusive=case"`
+	PanicIfInvalid  bool              `value:"false"`
+	Template        string            // template file overriding enum.tmpl, relative to the source file, see SetTemplateFiles
 }
 
 func (ec *Config) SetStringParse(stringParse bool) {
-	// if stringParse set to false, flag must be set to false
+	// if stringParse set to false, flag and bitmask must be set to false
 	if !stringParse {
 		ec.Flag = false
+		ec.Bitmask = false
 	}
 	ec.StringParse = stringParse
 }
 
 func (ec *Config) SetFlag(flag bool) {
-	// if set flag true, the stringParse must be set to true
+	// if set flag true, the stringParse must be set to true, the Set method of flag.Value conflicts with bitmask
 	if flag {
 		ec.StringParse = true
+		ec.Bitmask = false
 	}
 	ec.Flag = flag
 }
 
+func (ec *Config) SetBitmask(bitmask bool) {
+	// a bitmask is parsed from the names of its flags
+	if bitmask {
+		ec.StringParse = true
+		ec.Flag = false
+	}
+	ec.Bitmask = bitmask
+}
+
 func (ec *Config) SetForceLower(lower bool) {
 	if lower {
 		if ec.ForceUpper {
@@ -68,7 +91,7 @@ func (ec *Config) checkConfigAttributeName(paramName, errName string) error {
 		return fmt.Errorf("enum config %s must exist in enum attributes", errName)
 	} else {
 		// all enumTypes is number or string, bool and float not suitable as a map key
-		if !stream.Must(stream.Of(enumTypes).Contains(attr.Type, func(x, y reflect.Kind) (bool, error) { return x == y, nil })) {
+		if !attr.IsBuiltin() || !stream.Must(stream.Of(enumTypes).Contains(attr.Type, func(x, y reflect.Kind) (bool, error) { return x == y, nil })) {
 			return fmt.Errorf("%s 's type muse be number or string", errName)
 		}
 	}
@@ -89,5 +112,68 @@ func (ec *Config) CheckValid() (err error) {
 		return err
 	}
 
+	if ec.Gorm && !ec.Sql {
+		return fmt.Errorf("gorm data types of enum %s need sql", ec.enum.Name)
+	}
+
+	if err = ec.checkLabels(); err != nil {
+		return err
+	}
+
+	if ec.Bitmask {
+		if ec.TypeScript {
+			return fmt.Errorf("bitmask enum %s can not be exported to TypeScript", ec.enum.Name)
+		}
+		return ec.checkBitmask()
+	}
+
+	return nil
+}
+
+// checkBitmask checks that the combined values of a bitmask can be converted to and from strings, they are the names
+// of the flags joined by "|".
+func (ec *Config) checkBitmask() error {
+	if attr := ec.enum.FindAttributeByName(ec.StringParseName); attr.Type != reflect.String {
+		return fmt.Errorf("bitmask StringParseName %s's type must be string", attr.Name)
+	}
+
+	if ec.Marshal && !strings.EqualFold(ec.MarshalName, ec.StringParseName) {
+		return fmt.Errorf("bitmask MarshalName must be the StringParseName %s", ec.StringParseName)
+	}
+
+	if ec.Sql && !strings.EqualFold(ec.SqlName, ItemValue) && !strings.EqualFold(ec.SqlName, ec.StringParseName) {
+		return fmt.Errorf("bitmask SqlName must be %s or the StringParseName %s", ItemValue, ec.StringParseName)
+	}
+
+	return nil
+}
+
+// checkLabels checks that the label attributes are string attributes, the languages are unique in the form of the
+// generated code, and the LabelLang has label attributes.
+func (ec *Config) checkLabels() error {
+	langs := make([]string, 0, len(ec.Labels))
+	for lang := range ec.Labels {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	normalized := map[string]string{}
+	for _, lang := range langs {
+		name := ec.Labels[lang]
+		if attr := ec.enum.FindAttributeByName(name); attr == nil || attr.Type != reflect.String || !attr.IsBuiltin() {
+			return fmt.Errorf("label attribute %s of language %s must be a string attribute", name, lang)
+		}
+		if other, ok := normalized[labelLang(lang)]; ok {
+			return fmt.Errorf("languages %s and %s of Labels are the same language %s", other, lang, labelLang(lang))
+		}
+		normalized[labelLang(lang)] = lang
+	}
+
+	if len(ec.LabelLang) > 0 {
+		if _, ok := normalized[labelLang(ec.LabelLang)]; !ok {
+			return fmt.Errorf("LabelLang %s has no label attribute in Labels", ec.LabelLang)
+		}
+	}
+
 	return nil
 }
@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/peace0phmind/bud/bud/ast"
 	"github.com/peace0phmind/bud/stream"
 	"github.com/peace0phmind/bud/structure"
 	"github.com/peace0phmind/bud/util"
 	goast "go/ast"
+	"go/types"
+	"math/bits"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"text/template"
 )
 
 const (
@@ -17,27 +23,40 @@ const (
 )
 
 type Enum struct {
-	Name    string
-	Type    reflect.Kind
-	Comment string
-	Attrs   []*Attribute
-	Items   []*Item
-	Config  *Config
+	pos     lexer.Position
+	tmpl    *template.Template
+	file    *ast.File
+	spec    *goast.TypeSpec
+	imports []string
+	// protoType and protoValues bridge the enum to the protobuf enum of Config.Proto, see resolveProto.
+	protoType   string
+	protoValues []*ProtoValue
+	Name        string
+	Type        reflect.Kind
+	Comment     string
+	Attrs       []*Attribute
+	Items       []*Item
+	Config      *Config
 }
 
 func (e *Enum) UpdateAttributes(a *ast.Annotation) error {
 	if a.Params != nil && len(a.Params.List) > 0 {
 		for idx, p := range a.Params.List {
+			pos := ast.LexerPosition(p.Key.Pos)
 			if p.Value == nil {
-				return errors.New(fmt.Sprintf("Enum %s's attribute %s's type is empty", e.Name, p.Key.Text))
+				return ast.Errorf(pos, "Enum %s's attribute %s's type is empty", e.Name, p.Key.Text)
 			}
 			typeName, err := structure.ConvertTo[string](p.Value.Value())
 			if err != nil {
-				return errors.New(fmt.Sprintf("Enum %s's attribute %s's type parse error: %v", e.Name, p.Key.Text, err))
+				return ast.Errorf(pos, "Enum %s's attribute %s's type parse error: %v", e.Name, p.Key.Text, err)
 			}
+			var typ types.Type
 			t, err := getEnumAttributeKindByName(typeName)
 			if err != nil {
-				return errors.New(fmt.Sprintf("enum type err: %v", err))
+				if typ, err = e.resolveType(typeName); err != nil {
+					return ast.Errorf(pos, "enum type err: %v", err)
+				}
+				t = basicKind(typ)
 			}
 
 			comment := ast.GetCommentsText(p.Comments)
@@ -46,12 +65,15 @@ func (e *Enum) UpdateAttributes(a *ast.Annotation) error {
 			}
 
 			e.Attrs = append(e.Attrs, &Attribute{
-				enum:    e,
-				idx:     idx,
-				isValue: false,
-				Name:    util.Capitalize(p.Key.Text),
-				Type:    t,
-				Comment: comment,
+				enum:     e,
+				pos:      p.Key.Pos,
+				idx:      idx,
+				isValue:  false,
+				Name:     util.Capitalize(p.Key.Text),
+				Type:     t,
+				TypeName: typeName,
+				Comment:  comment,
+				typ:      typ,
 			})
 		}
 	}
@@ -59,6 +81,29 @@ func (e *Enum) UpdateAttributes(a *ast.Annotation) error {
 	return nil
 }
 
+// resolveType type checks the attribute type name, which can be a named type of the package or an imported package
+// like time.Duration. The packages used by the type are added to the imports of the enum.
+func (e *Enum) resolveType(typeName string) (types.Type, error) {
+	tv, imports, err := e.file.Eval(e.spec.Pos(), typeName)
+	if err != nil {
+		return nil, fmt.Errorf("unknown attribute type %s: %v", typeName, err)
+	}
+	if !tv.IsType() {
+		return nil, fmt.Errorf("attribute type %s is not a type", typeName)
+	}
+
+	e.addImports(imports...)
+	return tv.Type, nil
+}
+
+func (e *Enum) addImports(imports ...string) {
+	for _, imp := range imports {
+		if !stream.Must(stream.Of(e.imports).Contains(imp, func(x, y string) (bool, error) { return x == y, nil })) {
+			e.imports = append(e.imports, imp)
+		}
+	}
+}
+
 func (e *Enum) UpdateItems(a *ast.Annotation) error {
 	if a.Extends != nil && len(a.Extends.List) > 0 {
 		for idx, ex := range a.Extends.List {
@@ -69,18 +114,20 @@ func (e *Enum) UpdateItems(a *ast.Annotation) error {
 
 			ei := &Item{
 				enum:        e,
+				pos:         ex.Name.Pos,
 				idx:         idx,
 				Name:        ex.Name.Text,
 				Value:       value,
 				DocComment:  ast.GetCommentsText(ex.Comments),
 				LineComment: ast.GetCommentText(ex.Comment),
+				transitions: ex.Transitions,
 			}
 
 			if ei.Name == BlankIdentifier {
 				ei.IsBlankIdentifier = true
 			} else {
 				if len(e.Attrs) != len(ex.Values) {
-					return errors.New("enum data number not equals with enum attribute type")
+					return ast.Errorf(ast.LexerPosition(ex.Name.Pos), "enum item %s has %d attribute values, but enum %s has %d attributes", ex.Name.Text, len(ex.Values), e.Name, len(e.Attrs))
 				}
 			}
 
@@ -95,7 +142,7 @@ func (e *Enum) UpdateItems(a *ast.Annotation) error {
 		return nil
 	}
 
-	return errors.New("Enum must have some items")
+	return ast.Errorf(ast.LexerPosition(a.Name.Pos), "Enum %s must have some items", e.Name)
 }
 
 func (e *Enum) CheckValid() (err error) {
@@ -107,6 +154,10 @@ func (e *Enum) CheckValid() (err error) {
 		return err
 	}
 
+	if err = e.checkTransitions(); err != nil {
+		return err
+	}
+
 	if err = e.checkAndUpdateNameAttribute(); err != nil {
 		return err
 	}
@@ -115,6 +166,10 @@ func (e *Enum) CheckValid() (err error) {
 		return err
 	}
 
+	if err = e.checkItemAttributeData(); err != nil {
+		return err
+	}
+
 	if err = e.Config.CheckValid(); err != nil {
 		return err
 	}
@@ -128,13 +183,80 @@ func (e *Enum) checkItemNameUnique() error {
 	itemNames := make(map[string]bool)
 	for _, item := range e.GetItems() {
 		if itemNames[item.Name] {
-			return fmt.Errorf("enum item names must be unique, %s", item.Name)
+			return ast.Errorf(ast.LexerPosition(item.pos), "enum item names must be unique, %s", item.Name)
 		}
 		itemNames[item.Name] = true
 	}
 	return nil
 }
 
+// checkTransitions resolves the transitions of the items to the items they name.
+func (e *Enum) checkTransitions() error {
+	items := map[string]*Item{}
+	for _, item := range e.GetItems() {
+		items[item.Name] = item
+	}
+
+	for _, item := range e.Items {
+		if item.IsBlankIdentifier && len(item.transitions) > 0 {
+			return ast.Errorf(ast.LexerPosition(item.pos), "blank enum item can not have transitions")
+		}
+
+		for _, name := range item.transitions {
+			next, ok := items[name.Text]
+			if !ok {
+				return ast.Errorf(ast.LexerPosition(name.Pos), "enum item %s's transition %s is not an item of enum %s", item.Name, name.Text, e.Name)
+			}
+			if stream.Must(stream.Of(item.Next).Contains(next, func(x, y *Item) (bool, error) { return x == y, nil })) {
+				return ast.Errorf(ast.LexerPosition(name.Pos), "enum item %s's transition %s is duplicated", item.Name, name.Text)
+			}
+			item.Next = append(item.Next, next)
+		}
+	}
+
+	return nil
+}
+
+// HasTransitions reports whether an item of the enum declares transitions.
+func (e *Enum) HasTransitions() bool {
+	return stream.Must(stream.Of(e.Items).AnyMatch(func(item *Item) (bool, error) { return len(item.Next) > 0, nil }))
+}
+
+// TransitionsDOT returns the transition graph of the items in the DOT language of graphviz.
+func (e *Enum) TransitionsDOT() string {
+	buf := bytes.NewBufferString(fmt.Sprintf("digraph %s {\n", e.Name))
+	for _, item := range e.GetItems() {
+		if len(item.Next) == 0 {
+			buf.WriteString(fmt.Sprintf("\t%q;\n", item.GetName()))
+		}
+		for _, next := range item.Next {
+			buf.WriteString(fmt.Sprintf("\t%q -> %q;\n", item.GetName(), next.GetName()))
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// TransitionsMermaid returns the transition graph of the items as a mermaid state diagram. The states are the item
+// identifiers, labeled with the item names if they differ.
+func (e *Enum) TransitionsMermaid() string {
+	buf := bytes.NewBufferString("stateDiagram-v2\n")
+	for _, item := range e.GetItems() {
+		if item.GetName() != item.Name {
+			buf.WriteString(fmt.Sprintf("    state %q as %s\n", item.GetName(), item.Name))
+		}
+	}
+	for _, item := range e.GetItems() {
+		if len(item.Next) == 0 {
+			buf.WriteString(fmt.Sprintf("    %s\n", item.Name))
+		}
+		for _, next := range item.Next {
+			buf.WriteString(fmt.Sprintf("    %s --> %s\n", item.Name, next.Name))
+		}
+	}
+	return buf.String()
+}
+
 // checkAttributeNameUnique checks if the attribute names in the Enum are unique.
 // It returns an error if a duplicate name is found.
 func (e *Enum) checkAttributeNameUnique() error {
@@ -142,7 +264,7 @@ func (e *Enum) checkAttributeNameUnique() error {
 	attributeNames := make(map[string]bool)
 	for _, ex := range e.Attrs {
 		if attributeNames[ex.Name] {
-			return fmt.Errorf("enum attribute names must be unique, %s", ex.Name)
+			return ast.Errorf(ast.LexerPosition(ex.pos), "enum attribute names must be unique, %s", ex.Name)
 		}
 		attributeNames[ex.Name] = true
 	}
@@ -160,12 +282,14 @@ func (e *Enum) checkAndUpdateNameAttribute() error {
 		}
 
 		nameAttr = &Attribute{
-			enum:    e,
-			idx:     0,
-			isValue: false,
-			Name:    ItemName,
-			Type:    reflect.String,
-			Comment: "",
+			enum:     e,
+			pos:      e.pos,
+			idx:      0,
+			isValue:  false,
+			Name:     ItemName,
+			Type:     reflect.String,
+			TypeName: reflect.String.String(),
+			Comment:  "",
 		}
 		e.Attrs = append([]*Attribute{nameAttr}, e.Attrs...)
 
@@ -173,8 +297,8 @@ func (e *Enum) checkAndUpdateNameAttribute() error {
 			ei.AttributeData = append([]any{ei.Name}, ei.AttributeData...)
 		}
 	} else {
-		if nameAttr.Type != reflect.String {
-			return errors.New("enum attribute 'Name' must have type string")
+		if nameAttr.Type != reflect.String || !nameAttr.IsBuiltin() {
+			return ast.Errorf(ast.LexerPosition(nameAttr.pos), "enum attribute 'Name' must have type string")
 		}
 
 		for _, ei := range e.GetItems() {
@@ -189,26 +313,36 @@ func (e *Enum) checkAndUpdateNameAttribute() error {
 
 func (e *Enum) checkAndUpdateValueAttribute() error {
 	if valueAttr := e.FindAttributeByName(ItemValue); valueAttr != nil {
-		return errors.New("\"Value\" is a reserved attribute in enum and cannot appear in named parameters. However, it can be directly specified after \"=\".")
+		return ast.Errorf(ast.LexerPosition(valueAttr.pos), "\"Value\" is a reserved attribute in enum and cannot appear in named parameters. However, it can be directly specified after \"=\".")
 	}
 
 	valueAttr := &Attribute{
-		enum:    e,
-		idx:     -1,
-		isValue: true,
-		Name:    ItemValue,
-		Type:    e.Type,
-		Comment: "",
+		enum:     e,
+		pos:      e.pos,
+		idx:      -1,
+		isValue:  true,
+		Name:     ItemValue,
+		Type:     e.Type,
+		TypeName: e.Type.String(),
+		Comment:  "",
 	}
 	e.Attrs = append(e.Attrs, valueAttr)
 
 	// check and set item value
+	if e.Config.Bitmask {
+		return e.updateBitmaskValues()
+	}
+
 	if e.Type == reflect.String {
 		for _, ei := range e.GetItems() {
 			if ei.Value == nil {
 				ei.Value = ei.GetName()
 			} else {
-				ei.Value = structure.MustConvertTo[string](ei.Value)
+				value, err := structure.ConvertTo[string](ei.Value)
+				if err != nil {
+					return ast.Errorf(ast.LexerPosition(ei.pos), "enum item %s's value %v is not a string: %v", ei.Name, ei.Value, err)
+				}
+				ei.Value = value
 			}
 		}
 	} else {
@@ -219,10 +353,99 @@ func (e *Enum) checkAndUpdateValueAttribute() error {
 					item.Value = nextValue
 					nextValue += 1
 				} else {
-					item.Value = structure.MustConvertTo[int](item.Value)
-					nextValue = item.Value.(int) + 1
+					value, err := structure.ConvertTo[int](item.Value)
+					if err != nil {
+						return ast.Errorf(ast.LexerPosition(item.pos), "enum item %s's value %v is not a number: %v", item.Name, item.Value, err)
+					}
+					item.Value = value
+					nextValue = value + 1
 				}
-				item.Value = structure.MustConvertToKind(item.Value, e.Type)
+				value, err := structure.ConvertToKind(item.Value, e.Type)
+				if err != nil {
+					return ast.Errorf(ast.LexerPosition(item.pos), "enum item %s's value %v is not %s: %v", item.Name, item.Value, e.Type, err)
+				}
+				item.Value = value
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateBitmaskValues sets the values of the items of a bitmask enum. An item without value gets the bit following
+// the bit of the previous item, starting at 1, and a blank identifier skips a bit. A given value must be a power of
+// two or 0, which names the empty set.
+func (e *Enum) updateBitmaskValues() error {
+	if e.Type == reflect.String {
+		return ast.Errorf(ast.LexerPosition(e.pos), "bitmask enum %s must have an integer type", e.Name)
+	}
+
+	size := reflect.TypeOf(structure.MustConvertToKind(0, e.Type)).Bits()
+	if e.Type >= reflect.Int && e.Type <= reflect.Int64 {
+		// the sign bit is not a flag
+		size--
+	}
+
+	bit := 0
+	for _, item := range e.Items {
+		if item.Value != nil {
+			value, err := structure.ConvertTo[uint64](item.Value)
+			if err != nil || value&(value-1) != 0 {
+				return ast.Errorf(ast.LexerPosition(item.pos), "bitmask enum item %s's value %v is not a power of two", item.Name, item.Value)
+			}
+			if value == 0 {
+				item.Value = structure.MustConvertToKind(0, e.Type)
+				continue
+			}
+			bit = bits.TrailingZeros64(value)
+		}
+
+		if bit >= size {
+			return ast.Errorf(ast.LexerPosition(item.pos), "bitmask enum item %s exceeds the %d bits of %s", item.Name, size, e.Type)
+		}
+		item.Value = structure.MustConvertToKind(uint64(1)<<bit, e.Type)
+		bit++
+	}
+
+	return nil
+}
+
+// BitmaskFlags returns the items of a bitmask enum having a bit set, the item of the value 0 is left out.
+func (e *Enum) BitmaskFlags() []*Item {
+	return stream.Must(stream.Of(e.GetItems()).Filter(func(item *Item) (bool, error) {
+		return fmt.Sprint(item.Value) != "0", nil
+	}).ToSlice())
+}
+
+// BitmaskMask returns the go expression of the union of the flags of a bitmask enum.
+func (e *Enum) BitmaskMask() string {
+	names := stream.Must(stream.Map[*Item, string](stream.Of(e.BitmaskFlags()), func(item *Item) (string, error) {
+		return item.GetCodeName(), nil
+	}).ToSlice())
+	if len(names) == 0 {
+		return e.EmptyEnumValue()
+	}
+	return strings.Join(names, " | ")
+}
+
+// checkItemAttributeData checks if the attribute data of every item can be converted to the attribute type.
+// The data of an attribute having a named type is rendered to go code here, see Attribute.renderValue.
+func (e *Enum) checkItemAttributeData() error {
+	for _, item := range e.GetItems() {
+		for _, attr := range e.Attrs {
+			if attr.isValue || isBlankIdentifier(item.AttributeData[attr.idx]) {
+				continue
+			}
+
+			if !attr.IsBuiltin() {
+				if err := attr.renderValue(item); err != nil {
+					return ast.Errorf(ast.LexerPosition(item.pos), "enum item %s's attribute %s value %v is not %s: %v", item.Name, attr.Name, item.AttributeData[attr.idx], attr.TypeName, err)
+				}
+				continue
+			}
+
+			if _, err := structure.ConvertToKind(item.AttributeData[attr.idx], attr.Type); err != nil {
+				return ast.Errorf(ast.LexerPosition(item.pos), "enum item %s's attribute %s value %v is not %s: %v", item.Name, attr.Name, item.AttributeData[attr.idx], attr.Type, err)
 			}
 		}
 	}
@@ -230,6 +453,19 @@ func (e *Enum) checkAndUpdateValueAttribute() error {
 	return nil
 }
 
+// templateFiles returns the template files of the enum, the template of the enum config is relative to dir.
+func (e *Enum) templateFiles(dir string) []string {
+	files := templateFiles
+	if len(e.Config.Template) > 0 {
+		tmplFile := e.Config.Template
+		if !filepath.IsAbs(tmplFile) {
+			tmplFile = filepath.Join(dir, tmplFile)
+		}
+		files = append(append([]string{}, files...), tmplFile)
+	}
+	return files
+}
+
 func (e *Enum) Names() string {
 	buf := bytes.NewBuffer([]byte{})
 	buf.WriteString(fmt.Sprintf("var _%sNames = []string{\n", e.Name))
@@ -287,7 +523,7 @@ func annotationGroupToEnumConfig(ag *ast.AnnotationGroup, globalConfig *Config)
 	if enumConfAnnotation != nil {
 		ec, err := ast.AnnotationParamsTo[Config](structure.Clone(globalConfig), enumConfAnnotation)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("pase annotation err: %v", err))
+			return nil, withPos(err, enumConfAnnotation.Name.Pos)
 		}
 		return ec, nil
 	}
@@ -299,7 +535,7 @@ func annotationGroupToEnumConfig(ag *ast.AnnotationGroup, globalConfig *Config)
 	}
 }
 
-func annotationGroupToEnum(ag *ast.AnnotationGroup, ts *goast.TypeSpec, globalConfig *Config) (*Enum, error) {
+func annotationGroupToEnum(ag *ast.AnnotationGroup, file *ast.File, ts *goast.TypeSpec, globalConfig *Config) (*Enum, error) {
 	enumAnnotation := ag.FindAnnotationByName("enum")
 	if enumAnnotation == nil {
 		return nil, nil
@@ -311,13 +547,16 @@ func annotationGroupToEnum(ag *ast.AnnotationGroup, ts *goast.TypeSpec, globalCo
 	}
 
 	enum := &Enum{
+		pos:    enumAnnotation.Name.Pos,
+		file:   file,
+		spec:   ts,
 		Config: ec,
 	}
 	ec.enum = enum
 
 	t, err := getEnumKindByName(fmt.Sprintf("%s", ts.Type))
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("enum type err: %v", err))
+		return nil, ast.Errorf(ast.LexerPosition(enum.pos), "enum type err: %v", err)
 	}
 
 	enum.Name = ts.Name.Name
@@ -340,8 +579,32 @@ func annotationGroupToEnum(ag *ast.AnnotationGroup, ts *goast.TypeSpec, globalCo
 
 	err = enum.CheckValid()
 	if err != nil {
-		return nil, err
+		return nil, withPos(err, enum.pos)
+	}
+
+	if ec.Gorm {
+		enum.addImports("gorm.io/gorm", "gorm.io/gorm/schema")
+	}
+
+	if ec.JSONSchema {
+		enum.addImports("encoding/json", "github.com/peace0phmind/bud/jsonschema")
+	}
+
+	if len(ec.Proto) > 0 {
+		if err = enum.resolveProto(); err != nil {
+			return nil, withPos(err, enum.pos)
+		}
 	}
 
 	return enum, nil
 }
+
+// withPos returns err as an ast.Diagnostic located at pos, unless it already has a position.
+func withPos(err error, pos lexer.Position) error {
+	var diagnostic *ast.Diagnostic
+	var diagnostics ast.Diagnostics
+	if errors.As(err, &diagnostic) || errors.As(err, &diagnostics) {
+		return err
+	}
+	return ast.Errorf(ast.LexerPosition(pos), "%v", err)
+}
@@ -8,14 +8,23 @@ import (
 	"github.com/peace0phmind/bud/stream"
 	"github.com/peace0phmind/bud/util"
 	goast "go/ast"
-	"go/token"
+	"io"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 )
 
-//go:embed enum.tmpl
+//go:embed enum.tmpl typescript.tmpl
 var enumTmpl embed.FS
 
+func init() {
+	ast.RegisterGenerator("enum", NewGenerator)
+	ast.RegisterSchema(ast.NewSchema[Config]("EnumConfig"))
+	// the params of @ENUM are the attributes of the items
+	ast.RegisterSchema(&ast.Schema{Name: "ENUM", AllowUnknown: true})
+}
+
 type EnumGenerator struct {
 	ast.BaseGenerator[Enum]
 }
@@ -28,81 +37,223 @@ func wrapType(inner string, sourceType string, targetType string) string {
 	return fmt.Sprintf("%s(%s)", targetType, inner)
 }
 
-func newEnumGenerator(allEnums []*Enum) *EnumGenerator {
-	result := &EnumGenerator{}
+// rawQuote returns s as a go raw string literal, or an interpreted string literal if s holds a backquote.
+func rawQuote(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
 
+var baseTmpl = newBaseTemplate()
+
+func newBaseTemplate() *template.Template {
 	tmpl := template.New("enum")
 
 	funcs := template.FuncMap{}
 	funcs["IA"] = util.IndefiniteArticle
 	funcs["WT"] = wrapType
+	funcs["RQ"] = rawQuote
 	tmpl.Funcs(funcs)
 
-	result.Tmpl = template.Must(tmpl.ParseFS(enumTmpl, "*.tmpl"))
+	return template.Must(tmpl.ParseFS(enumTmpl, "*.tmpl"))
+}
+
+var templateFiles []string
+
+// SetTemplateFiles sets the template files applied to all enums, file names can be glob patterns.
+// A template file can redefine the "const", "init", "body" and "typescript" sections of the enum templates, or add
+// extra blocks to them by defining templates named like "body.audit". Extra blocks are executed after their section in
+// name order.
+func SetTemplateFiles(files ...string) {
+	templateFiles = files
+}
+
+// loadTemplate returns the enum.tmpl overridden by the template files, it caches the loaded templates in cache.
+func loadTemplate(cache map[string]*template.Template, files []string) (*template.Template, error) {
+	if len(files) == 0 {
+		return baseTmpl, nil
+	}
+
+	key := strings.Join(files, "\n")
+	if tmpl, ok := cache[key]; ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := baseTmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pattern := range files {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("enum template %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("enum template %s: no such file", pattern)
+		}
+
+		if _, err = tmpl.ParseFiles(matches...); err != nil {
+			return nil, err
+		}
+	}
+
+	cache[key] = tmpl
+	return tmpl, nil
+}
+
+func newEnumGenerator(allEnums []*Enum) *EnumGenerator {
+	result := &EnumGenerator{}
+
+	result.Tmpl = baseTmpl
 
 	result.DataList = stream.Must(stream.Of(allEnums).Sort(func(x, y *Enum) int { return strings.Compare(x.Name, y.Name) }).ToSlice())
 
 	return result
 }
 
+// ExecuteTemplate executes the section name with the template of every enum.
+func (eg *EnumGenerator) ExecuteTemplate(wr io.Writer, name string) error {
+	for _, e := range eg.DataList {
+		if err := ast.ExecuteSection(e.tmpl, wr, name, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (eg *EnumGenerator) WriteConst(wr io.Writer) error {
+	return eg.ExecuteTemplate(wr, "const")
+}
+
+func (eg *EnumGenerator) WriteInitFunc(wr io.Writer) error {
+	return eg.ExecuteTemplate(wr, "init")
+}
+
+func (eg *EnumGenerator) WriteBody(wr io.Writer) error {
+	return eg.ExecuteTemplate(wr, "body")
+}
+
+// ExtraFileExts returns ".ts" if an enum is exported to TypeScript.
+func (eg *EnumGenerator) ExtraFileExts() []string {
+	for _, e := range eg.DataList {
+		if e.Config.TypeScript {
+			return []string{".ts"}
+		}
+	}
+	return nil
+}
+
+// WriteExtraFile writes the TypeScript section of the enums exported to TypeScript.
+func (eg *EnumGenerator) WriteExtraFile(wr io.Writer, ext string) error {
+	for _, e := range eg.DataList {
+		if ext == ".ts" && e.Config.TypeScript {
+			if err := ast.ExecuteSection(e.tmpl, wr, "typescript", e); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetImports returns the imports of the enum template, and the packages used by the named attribute types.
 func (eg *EnumGenerator) GetImports() []string {
-	return []string{"errors", "fmt"}
+	imports := []string{"errors", "fmt"}
+	for _, e := range eg.DataList {
+		imports = append(imports, e.imports...)
+	}
+	return imports
 }
 
-func NewGenerator(fileNode *goast.File, fileSet *token.FileSet) (ast.Generator, error) {
+func NewGenerator(file *ast.File) (ast.Generator, error) {
+	var diagnostics ast.Diagnostics
+	fileNode, fileSet := file.Node, file.FileSet
+
+	// get the enum config of the project, it is overridden by the global enum config of the file
+	ec, err := annotationGroupToEnumConfig(&file.Defaults, factory.New[Config]())
+	if err != nil {
+		diagnostics.Append(err, fileSet.Position(fileNode.Package))
+		return nil, diagnostics
+	}
+
 	// get global enum config
-	var ec *Config = nil
 	for _, cg := range fileNode.Comments {
 		if strings.HasPrefix(cg.List[len(cg.List)-1].Text, "//go:generate") {
-			ag, err := ast.ParseAnnotation(fileNode.Name.Name, cg.Text())
+			ag, err := file.ParseCommentGroup(cg)
 			if err != nil {
-				return nil, fmt.Errorf("parse annotation err: %v", err)
+				diagnostics.Append(err, fileSet.Position(cg.Pos()))
+				break
 			}
 
-			ec1, err := annotationGroupToEnumConfig(ag, nil)
+			ec1, err := annotationGroupToEnumConfig(ag, ec)
 			if err != nil {
-				return nil, fmt.Errorf("parse annotation to enum config err: %v", err)
+				diagnostics.Append(err, fileSet.Position(cg.Pos()))
+				break
 			}
 			ec = ec1
 			break
 		}
 	}
 
-	if ec == nil {
-		ec = factory.New[Config]()
-	}
+	dir := filepath.Dir(fileSet.Position(fileNode.Package).Filename)
+	tmplCache := map[string]*template.Template{}
 
 	// get all enums
 	allEnums := ast.InspectMapper[goast.TypeSpec, Enum](fileNode, fileSet, func(ts *goast.TypeSpec) *Enum {
-		var comment string
-		if ts.Doc != nil {
-			comment = ts.Doc.Text()
-		} else if ts.Comment != nil {
-			comment = ts.Comment.Text()
+		cg := ts.Doc
+		if cg == nil {
+			cg = ts.Comment
 		}
 
-		if len(comment) > 0 && (strings.Contains(comment, "@e") || strings.Contains(comment, "@E")) {
-			ag, err1 := ast.ParseAnnotation(ts.Name.Name, comment)
-			if err1 != nil {
-				fmt.Printf("parse annotation err: %v", err1)
-				return nil
-			}
+		if cg != nil {
+			comment := cg.Text()
+			if len(comment) > 0 && (strings.Contains(comment, "@e") || strings.Contains(comment, "@E")) {
+				ag, err := file.ParseCommentGroup(cg)
+				if err != nil {
+					diagnostics.Append(err, fileSet.Position(cg.Pos()))
+					return nil
+				}
 
-			enum, err := annotationGroupToEnum(ag, ts, ec)
-			if err != nil {
-				panic(fmt.Sprintf("get enum err: %v", err))
-			}
+				enum, err := annotationGroupToEnum(ag, file, ts, ec)
+				if err != nil {
+					if file.Annotated(cg) {
+						diagnostics.Append(err, fileSet.Position(ts.Pos()))
+					} else {
+						diagnostics.Add(ast.Warnings(err, fileSet.Position(ts.Pos()))...)
+					}
+					return nil
+				}
+
+				if enum != nil {
+					enum.tmpl, err = loadTemplate(tmplCache, enum.templateFiles(dir))
+					if err != nil {
+						diagnostics.Append(withPos(err, enum.pos), fileSet.Position(ts.Pos()))
+						return nil
+					}
+				}
+
+				if enum == nil && ag.FindAnnotationByName("EnumConfig") != nil {
+					diagnostics.Add(ast.Warningf(fileSet.Position(ts.Pos()), "@EnumConfig of type %s is ignored without @ENUM", ts.Name.Name))
+				}
 
-			return enum
+				return enum
+			}
 		}
 
 		return nil
 	})
 
+	if diagnostics.HasErrors() {
+		return nil, diagnostics
+	}
+
 	if len(allEnums) > 0 {
 		// create enums
-		return newEnumGenerator(allEnums), nil
+		return newEnumGenerator(allEnums), diagnostics.Err()
 	}
 
-	return nil, nil
+	return nil, diagnostics.Err()
 }
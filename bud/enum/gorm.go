@@ -0,0 +1,72 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// sqlAttribute returns the attribute the enum is stored as in the database, the SqlName attribute.
+func (e *Enum) sqlAttribute() *Attribute {
+	return e.FindAttributeByName(e.Config.SqlName)
+}
+
+// GormDataType returns the gorm data type of the values the enum is stored as, like string or int.
+func (e *Enum) GormDataType() string {
+	switch kind := e.sqlAttribute().Type; {
+	case kind == reflect.String:
+		return "string"
+	case kind == reflect.Bool:
+		return "bool"
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		return "int"
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		return "uint"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "float"
+	default:
+		return kind.String()
+	}
+}
+
+// GormDataSize returns the bits of the numbers the enum is stored as, 0 for the other values. gorm sizes the column
+// by the kind of the field, which is not the kind of the stored value when the SqlName attribute is not the value.
+func (e *Enum) GormDataSize() int {
+	switch kind := e.sqlAttribute().Type; kind {
+	case reflect.Int, reflect.Uint, reflect.Int64, reflect.Uint64, reflect.Float64:
+		return 64
+	case reflect.Int8, reflect.Uint8:
+		return 8
+	case reflect.Int16, reflect.Uint16:
+		return 16
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// GormSqlValues returns the values the items are stored as in the database as a list of SQL literals like
+// 'pending', 'done', the items leaving the SqlName attribute blank and repeated values are skipped.
+func (e *Enum) GormSqlValues() string {
+	attr := e.sqlAttribute()
+	seen := map[string]bool{}
+
+	var values []string
+	for _, item := range e.GetItems() {
+		value := attr.jsonValue(item)
+		if value == nil {
+			continue
+		}
+
+		literal := fmt.Sprintf("%v", value)
+		if attr.Type == reflect.String {
+			literal = "'" + strings.ReplaceAll(literal, "'", "''") + "'"
+		}
+		if !seen[literal] {
+			seen[literal] = true
+			values = append(values, literal)
+		}
+	}
+	return strings.Join(values, ", ")
+}
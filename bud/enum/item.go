@@ -2,7 +2,9 @@ package enum
 
 import (
 	"fmt"
+	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/iancoleman/strcase"
+	"github.com/peace0phmind/bud/bud/ast"
 	"github.com/peace0phmind/bud/util"
 	"reflect"
 	"strings"
@@ -15,6 +17,7 @@ const (
 
 type Item struct {
 	enum              *Enum
+	pos               lexer.Position
 	idx               int
 	Name              string
 	Value             any
@@ -22,6 +25,9 @@ type Item struct {
 	LineComment       string
 	AttributeData     []any
 	IsBlankIdentifier bool
+	transitions       []*ast.Name
+	// Next are the items the item can transition to, declared like pending -> inWork, rejected.
+	Next []*Item
 }
 
 // GetCodeName return the item name used in code
@@ -30,12 +36,7 @@ func (ei *Item) GetCodeName() string {
 		return BlankIdentifier
 	}
 
-	casedName := ei.Name
-	if ei.enum.Config.NoCamel {
-		casedName = util.Capitalize(ei.Name)
-	} else {
-		casedName = strcase.ToCamel(ei.Name)
-	}
+	casedName := ei.GetCasedName()
 
 	if ei.enum.Config.NoPrefix {
 		return ei.enum.Config.Prefix + casedName
@@ -44,6 +45,14 @@ func (ei *Item) GetCodeName() string {
 	}
 }
 
+// GetCasedName return the item name in camel case, or capitalized if NoCamel is set, it is the code name without prefix
+func (ei *Item) GetCasedName() string {
+	if ei.enum.Config.NoCamel {
+		return util.Capitalize(ei.Name)
+	}
+	return strcase.ToCamel(ei.Name)
+}
+
 // GetName return the item real name, default equals with the code name, or an attribute named `Name`
 func (ei *Item) GetName() string {
 	nameAttr := ei.enum.FindAttributeByName(ItemName)
@@ -0,0 +1,153 @@
+package enum
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/peace0phmind/bud/structure"
+	"reflect"
+	"strings"
+)
+
+// jsonSchema is the JSON schema of an enum, the fields are in the order of the generated schema.
+type jsonSchema struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	Type        string           `json:"type"`
+	Enum        []any            `json:"enum,omitempty"`
+	OneOf       []map[string]any `json:"oneOf,omitempty"`
+	Flags       []map[string]any `json:"x-flags,omitempty"`
+}
+
+// JSONSchema returns the JSON schema of the values the enum is encoded to by encoding/json. The description of an
+// item is its doc and line comment, and the builtin attributes of the item are "x-" extensions like "x-code".
+// A bitmask is encoded as the combination of its flags, so its flags are listed in "x-flags" instead of "oneOf".
+func (e *Enum) JSONSchema() (string, error) {
+	schema := &jsonSchema{Title: e.Name, Description: e.docText(), Type: "integer"}
+	if e.encodedAsString() {
+		schema.Type = "string"
+	}
+
+	var items []map[string]any
+	for _, item := range e.GetItems() {
+		value := e.encodedValue(item)
+		entry := map[string]any{"const": value}
+		if description := joinComments(item.DocComment, item.LineComment); len(description) > 0 {
+			entry["description"] = description
+		}
+		for _, attr := range e.extraAttributes() {
+			if v := attr.jsonValue(item); v != nil {
+				entry["x-"+strcase.ToLowerCamel(attr.Name)] = v
+			}
+		}
+
+		schema.Enum = append(schema.Enum, value)
+		items = append(items, entry)
+	}
+
+	if e.Config.Bitmask {
+		schema.Enum = nil
+		schema.Flags = items
+	} else {
+		schema.OneOf = items
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("enum %s json schema: %w", e.Name, err)
+	}
+	return string(data), nil
+}
+
+// encodedAttribute returns the attribute the enum is encoded to by encoding/json, the MarshalName attribute with
+// Marshal, otherwise the value.
+func (e *Enum) encodedAttribute() *Attribute {
+	if e.Config.Marshal {
+		return e.FindAttributeByName(e.Config.MarshalName)
+	}
+	return e.FindAttributeByName(ItemValue)
+}
+
+// encodedAsString reports whether the enum is encoded to a JSON string, as a text with Marshal or as a string enum.
+func (e *Enum) encodedAsString() bool {
+	return e.Config.Marshal || e.Type == reflect.String
+}
+
+// encodedValue returns the value item is encoded to by encoding/json.
+func (e *Enum) encodedValue(item *Item) any {
+	value := e.encodedAttribute().jsonValue(item)
+	if e.encodedAsString() {
+		return fmt.Sprintf("%v", value)
+	}
+	return value
+}
+
+// extraAttributes returns the builtin attributes of the enum, except the one the enum is encoded to and the value.
+func (e *Enum) extraAttributes() []*Attribute {
+	encoded := e.encodedAttribute()
+
+	var result []*Attribute
+	for _, attr := range e.Attrs {
+		if attr != encoded && !attr.isValue && attr.IsBuiltin() {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
+// jsonValue returns the value of the attribute of item as a go value encoded by encoding/json, or nil if the item
+// leaves the attribute blank.
+func (ea *Attribute) jsonValue(item *Item) any {
+	switch {
+	case ea.isValue:
+		if item.Value == nil {
+			return item.idx
+		}
+		return item.Value
+	case ea.Name == ItemName:
+		return item.GetName()
+	case isBlankIdentifier(item.AttributeData[ea.idx]):
+		return nil
+	default:
+		return structure.MustConvertToKind(item.AttributeData[ea.idx], ea.Type)
+	}
+}
+
+// docText returns the doc of the enum type before its annotations, or the comments of @ENUM.
+func (e *Enum) docText() string {
+	cg := e.spec.Doc
+	if cg == nil {
+		cg = e.spec.Comment
+	}
+
+	var lines []string
+	if cg != nil {
+		for _, line := range strings.Split(cg.Text(), "\n") {
+			if i := strings.Index(line, "@"); i >= 0 {
+				lines = append(lines, line[:i])
+				break
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if text := joinComments(lines...); len(text) > 0 {
+		return text
+	}
+	return joinComments(e.Comment)
+}
+
+// joinComments joins the lines of the comments into one line without the comment markers.
+func joinComments(comments ...string) string {
+	var words []string
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimSpace(line)
+			line = strings.TrimPrefix(line, "//")
+			line = strings.TrimPrefix(line, "/*")
+			line = strings.TrimSuffix(line, "*/")
+			words = append(words, strings.Fields(line)...)
+		}
+	}
+	return strings.Join(words, " ")
+}
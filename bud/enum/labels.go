@@ -0,0 +1,48 @@
+package enum
+
+import (
+	"sort"
+	"strings"
+)
+
+// LabelTable is the labels of the items of the enum in a language.
+type LabelTable struct {
+	// Lang is the language in lower case like zh-tw.
+	Lang    string
+	Entries []LabelEntry
+}
+
+// LabelEntry is the label of an item.
+type LabelEntry struct {
+	Item  *Item
+	Label string
+}
+
+// labelLang returns the language tag in the form of the generated code, lower case with "-" like zh-tw.
+func labelLang(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
+
+// LabelTables returns the labels of the items by language sorted by language, an item leaving its label attribute
+// blank has no label in the language.
+func (e *Enum) LabelTables() []*LabelTable {
+	var result []*LabelTable
+	for lang, name := range e.Config.Labels {
+		attr := e.FindAttributeByName(name)
+		table := &LabelTable{Lang: labelLang(lang)}
+		for _, item := range e.GetItems() {
+			if label := attr.jsonValue(item); label != nil {
+				table.Entries = append(table.Entries, LabelEntry{Item: item, Label: label.(string)})
+			}
+		}
+		result = append(result, table)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Lang < result[j].Lang })
+	return result
+}
+
+// LabelFallback returns the language the labels fall back to in the form of the generated code, empty if not set.
+func (e *Enum) LabelFallback() string {
+	return labelLang(e.Config.LabelLang)
+}
@@ -0,0 +1,37 @@
+package enum
+
+import (
+	"bytes"
+	"github.com/peace0phmind/bud/bud/ast"
+	"github.com/stretchr/testify/assert"
+	goparser "go/parser"
+	"go/token"
+	"testing"
+)
+
+// generateBody runs the enum generator on the source file src, and returns the body of the bud file.
+func generateBody(t *testing.T, src string) (string, error) {
+	fileSet := token.NewFileSet()
+	fileNode, err := goparser.ParseFile(fileSet, "state.go", src, goparser.ParseComments)
+	assert.NoError(t, err)
+
+	g, err := NewGenerator(ast.NewFile(fileNode, fileSet, nil))
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.WriteBody(buf))
+	return buf.String(), nil
+}
+
+func TestLabelLanguages(t *testing.T) {
+	// the languages are compared in the form of the generated code
+	body, err := generateBody(t, "package labels\n\n// @EnumConfig(labels={en: LabelEn, zh_TW: LabelZhTw}, labelLang=EN)\n// @ENUM(LabelEn string, LabelZhTw string){on(\"On\", \"開\") off(\"Off\", _)}\ntype State int\n")
+	assert.NoError(t, err)
+	assert.Contains(t, body, `"zh-tw": {`)
+	assert.Contains(t, body, `_StateLabels["en"][x]`)
+
+	_, err = generateBody(t, "package labels\n\n// @EnumConfig(labels={zh_TW: LabelZhTw, \"zh-tw\": LabelTw})\n// @ENUM(LabelZhTw string, LabelTw string){on(\"開\", \"開\")}\ntype State int\n")
+	assert.EqualError(t, err, "state.go:4:5: error: languages zh-tw and zh_TW of Labels are the same language zh-tw")
+}
@@ -0,0 +1,393 @@
+package enum
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/peace0phmind/bud/bud/ast"
+	"github.com/peace0phmind/bud/proto/ext"
+	"github.com/peace0phmind/bud/structure"
+	goast "go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProtoValue is an item of the enum bridged to a value of the protobuf enum.
+type ProtoValue struct {
+	Item *Item
+	// Const is the go constant of the protobuf value, like typepb.Syntax_SYNTAX_PROTO3.
+	Const string
+}
+
+// ProtoType returns the go type of the protobuf enum used in the generated code, like typepb.Syntax.
+func (e *Enum) ProtoType() string {
+	return e.protoType
+}
+
+// ProtoValues returns the items of the enum with their protobuf values.
+func (e *Enum) ProtoValues() []*ProtoValue {
+	return e.protoValues
+}
+
+// resolveProto resolves the protobuf enum of the Proto config, and matches its values to the items by name, or by the
+// ProtoName attribute. A value with the enumgoname option of proto/ext matches the item of that name first.
+// An item leaving the ProtoName attribute blank has no protobuf value.
+func (e *Enum) resolveProto() error {
+	named, qualifier, err := e.lookupProtoType(e.Config.Proto)
+	if err != nil {
+		return err
+	}
+	if basic, ok := named.Underlying().(*types.Basic); !ok || basic.Kind() != types.Int32 {
+		return fmt.Errorf("protobuf enum %s must have type int32", e.Config.Proto)
+	}
+
+	// the values of the protobuf enum are the constants of its type in declaration order
+	var consts []*types.Const
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	sort.SliceStable(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	var nameAttr *Attribute
+	if len(e.Config.ProtoName) > 0 {
+		if nameAttr = e.FindAttributeByName(e.Config.ProtoName); nameAttr == nil || nameAttr.Type != reflect.String || !nameAttr.IsBuiltin() {
+			return fmt.Errorf("enum config ProtoName %s must be a string attribute of enum %s", e.Config.ProtoName, e.Name)
+		}
+	}
+
+	options, err := readProtoOptions(named)
+	if err != nil {
+		return fmt.Errorf("protobuf enum %s: %w", e.Config.Proto, err)
+	}
+	if err = options.checkGoTypes(e.Name, e.file.Node.Name.Name); err != nil {
+		return err
+	}
+
+	e.protoType = qualifier + "." + named.Obj().Name()
+	matched := map[*types.Const]*Item{}
+	for _, item := range e.GetItems() {
+		name := item.Name
+		if nameAttr != nil {
+			if isBlankIdentifier(item.AttributeData[nameAttr.idx]) {
+				continue
+			}
+			name = structure.MustConvertTo[string](item.AttributeData[nameAttr.idx])
+		}
+
+		c := options.matchGoName(consts, name)
+		if c == nil {
+			c = matchProtoConst(consts, named.Obj().Name(), name)
+		}
+		if c == nil {
+			return ast.Errorf(ast.LexerPosition(item.pos), "enum item %s has no value %s in protobuf enum %s", item.Name, name, e.Config.Proto)
+		}
+		if other, ok := matched[c]; ok {
+			return ast.Errorf(ast.LexerPosition(item.pos), "enum items %s and %s are the same protobuf value %s", other.Name, item.Name, c.Name())
+		}
+		matched[c] = item
+
+		e.protoValues = append(e.protoValues, &ProtoValue{Item: item, Const: qualifier + "." + c.Name()})
+	}
+
+	e.addImports("reflect", "github.com/peace0phmind/bud/structure")
+	return nil
+}
+
+// lookupProtoType returns the named type of the protobuf enum and the package qualifier of the generated code.
+// The type is qualified by a package imported by the package of the enum like pb.Status, or by an import path like
+// example.com/api/pb.Status.
+func (e *Enum) lookupProtoType(typeName string) (*types.Named, string, error) {
+	if i := strings.LastIndex(typeName, "."); i > 0 && strings.Contains(typeName[:i], "/") {
+		importPath, name := typeName[:i], typeName[i+1:]
+		named, err := e.file.LookupType(importPath, name)
+		if err != nil {
+			return nil, "", fmt.Errorf("protobuf enum %s: %v", typeName, err)
+		}
+
+		qualifier := named.Obj().Pkg().Name()
+		if qualifier == path.Base(importPath) {
+			e.addImports(importPath)
+		} else {
+			e.addImports(qualifier + " " + importPath)
+		}
+		return named, qualifier, nil
+	}
+
+	tv, imports, err := e.file.Eval(e.spec.Pos(), typeName)
+	if err != nil {
+		return nil, "", fmt.Errorf("unknown protobuf enum %s: %v", typeName, err)
+	}
+	e.addImports(imports...)
+	named, ok := tv.Type.(*types.Named)
+	if !tv.IsType() || !ok || !strings.Contains(typeName, ".") {
+		return nil, "", fmt.Errorf("protobuf enum %s must be a type of another package", typeName)
+	}
+	return named, typeName[:strings.LastIndex(typeName, ".")], nil
+}
+
+// protoKey normalizes a name for matching, like STATUS_IN_WORK and statusInWork.
+func protoKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// matchProtoConst returns the constant of the protobuf value name. A value matches by its go constant name, by its
+// protobuf name without the prefix of the go constant, which is the enum type like Status_ or the message of a nested
+// enum like Field_, or by its protobuf name without the prefix of the enum name like STATUS_.
+func matchProtoConst(consts []*types.Const, typeName string, name string) *types.Const {
+	prefix := typeName + "_"
+	enumName := typeName
+	if i := strings.LastIndex(typeName, "_"); i >= 0 {
+		prefix = typeName[:i+1]
+		enumName = typeName[i+1:]
+	}
+
+	key := protoKey(name)
+	for _, c := range consts {
+		value := strings.TrimPrefix(strings.TrimPrefix(c.Name(), typeName+"_"), prefix)
+		short := strings.TrimPrefix(protoKey(value), protoKey(enumName))
+		if key == protoKey(c.Name()) || key == protoKey(value) || key == short {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// protoOptions are the options of proto/ext declared for a protobuf enum in its protobuf file.
+type protoOptions struct {
+	// fullName is the protobuf name of the enum, like .google.protobuf.Syntax.
+	fullName string
+	// goNames are the enumgoname options of the values by the protoKey of their go constants.
+	goNames map[string]string
+	// fields are the message fields of the protobuf file with the gotype option.
+	fields []*protoField
+}
+
+// protoField is a message field of a protobuf file with the gotype option of proto/ext.
+type protoField struct {
+	Message string
+	// Name is the goname option of the field, or its protobuf name.
+	Name     string
+	TypeName string
+	GoType   string
+}
+
+// readProtoOptions reads the proto/ext options of the protobuf enum of the go type named from the raw descriptor in
+// the generated go file declaring it. A protobuf enum without descriptor has no options.
+func readProtoOptions(named *types.Named) (*protoOptions, error) {
+	options := &protoOptions{goNames: map[string]string{}}
+
+	fd, err := readProtoFileDescriptor(named)
+	if err != nil || fd == nil {
+		return options, err
+	}
+
+	typeKey := protoKey(named.Obj().Name())
+	var walk func(prefix string, goPrefix string, messages []*descriptorpb.DescriptorProto, enums []*descriptorpb.EnumDescriptorProto)
+	walk = func(prefix string, goPrefix string, messages []*descriptorpb.DescriptorProto, enums []*descriptorpb.EnumDescriptorProto) {
+		for _, enum := range enums {
+			goName := goPrefix + enum.GetName()
+			if protoKey(goName) != typeKey {
+				continue
+			}
+
+			options.fullName = prefix + "." + enum.GetName()
+			// the go constants of the values of a nested enum are prefixed with the message, like Field_LABEL_OPTIONAL
+			valuePrefix := goName
+			if len(goPrefix) > 0 {
+				valuePrefix = strings.TrimSuffix(goPrefix, "_")
+			}
+			for _, value := range enum.GetValue() {
+				if goName := protoStringOption(value.GetOptions(), ext.E_Enumgoname.Field); len(goName) > 0 {
+					options.goNames[protoKey(valuePrefix+"_"+value.GetName())] = goName
+				}
+			}
+		}
+
+		for _, message := range messages {
+			for _, field := range message.GetField() {
+				goType := protoStringOption(field.GetOptions(), ext.E_Gotype.Field)
+				if len(goType) == 0 {
+					continue
+				}
+
+				name := protoStringOption(field.GetOptions(), ext.E_Goname.Field)
+				if len(name) == 0 {
+					name = field.GetName()
+				}
+				options.fields = append(options.fields, &protoField{Message: strings.TrimPrefix(prefix+"."+message.GetName(), "."), Name: name, TypeName: field.GetTypeName(), GoType: goType})
+			}
+			walk(prefix+"."+message.GetName(), goPrefix+message.GetName()+"_", message.GetNestedType(), message.GetEnumType())
+		}
+	}
+
+	prefix := ""
+	if len(fd.GetPackage()) > 0 {
+		prefix = "." + fd.GetPackage()
+	}
+	walk(prefix, "", fd.GetMessageType(), fd.GetEnumType())
+	return options, nil
+}
+
+// readProtoFileDescriptor returns the descriptor of the protobuf file declaring the go type named, it is the raw
+// descriptor of protoc-gen-go like file_status_proto_rawDesc, or the gzipped descriptor of protoc-gen-gogo like
+// fileDescriptor_95fe6908ffcf64d3 in the generated go file. It returns nil if the file has no descriptor.
+func readProtoFileDescriptor(named *types.Named) (*descriptorpb.FileDescriptorProto, error) {
+	filename := ast.ImportedPosition(named.Obj().Pos()).Filename
+	if len(filename) == 0 {
+		return nil, nil
+	}
+
+	fileNode, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range fileNode.Decls {
+		gd, ok := decl.(*goast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*goast.ValueSpec)
+			if !ok || len(vs.Names) != 1 || len(vs.Values) != 1 {
+				continue
+			}
+
+			name := vs.Names[0].Name
+			gzipped := strings.HasPrefix(name, "fileDescriptor_")
+			if !gzipped && !(strings.HasPrefix(name, "file_") && strings.HasSuffix(name, "_rawDesc")) {
+				continue
+			}
+
+			data, err := protoRawDescriptor(named.Obj().Pkg(), name, vs.Values[0])
+			if err == nil && gzipped {
+				data, err = gunzip(data)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s of %s: %w", name, filename, err)
+			}
+
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err = proto.Unmarshal(data, fd); err != nil {
+				return nil, fmt.Errorf("%s of %s: %w", name, filename, err)
+			}
+			return fd, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// protoRawDescriptor returns the bytes of the descriptor declared as a byte slice literal, or as a string constant by
+// the newer versions of protoc-gen-go.
+func protoRawDescriptor(pkg *types.Package, name string, value goast.Expr) ([]byte, error) {
+	if c, ok := pkg.Scope().Lookup(name).(*types.Const); ok && c.Val().Kind() == constant.String {
+		return []byte(constant.StringVal(c.Val())), nil
+	}
+
+	cl, ok := value.(*goast.CompositeLit)
+	if !ok {
+		return nil, fmt.Errorf("not a byte slice literal")
+	}
+
+	data := make([]byte, 0, len(cl.Elts))
+	for _, elt := range cl.Elts {
+		lit, ok := elt.(*goast.BasicLit)
+		if !ok {
+			return nil, fmt.Errorf("not a byte slice literal")
+		}
+		b, err := strconv.ParseUint(lit.Value, 0, 8)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, byte(b))
+	}
+	return data, nil
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(zr)
+}
+
+// protoStringOption returns the string extension field of options, the options of proto/ext are unknown fields of
+// descriptorpb.
+func protoStringOption(options protoreflect.ProtoMessage, field int32) string {
+	if options == nil || !options.ProtoReflect().IsValid() {
+		return ""
+	}
+
+	b := options.ProtoReflect().GetUnknown()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return ""
+		}
+		b = b[n:]
+
+		if num == protowire.Number(field) && typ == protowire.BytesType {
+			v, _ := protowire.ConsumeBytes(b)
+			return string(v)
+		}
+
+		if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+			return ""
+		}
+		b = b[n:]
+	}
+	return ""
+}
+
+// matchGoName returns the constant of the protobuf value whose enumgoname option is name.
+func (po *protoOptions) matchGoName(consts []*types.Const, name string) *types.Const {
+	for _, c := range consts {
+		if goName, ok := po.goNames[protoKey(c.Name())]; ok && protoKey(goName) == protoKey(name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// checkGoTypes checks that the message fields whose gotype option is the enum enumName of package pkgName have the
+// type of the protobuf enum. The gotype is the name of the enum, qualified by its package name or import path.
+func (po *protoOptions) checkGoTypes(enumName string, pkgName string) error {
+	if len(po.fullName) == 0 {
+		return nil
+	}
+
+	for _, field := range po.fields {
+		qualifier, name := "", field.GoType
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			qualifier, name = name[:i], name[i+1:]
+		}
+		if name != enumName || (len(qualifier) > 0 && path.Base(qualifier) != pkgName) {
+			continue
+		}
+
+		if field.TypeName != po.fullName {
+			return fmt.Errorf("protobuf field %s of %s has gotype %s, but its type %s is not the protobuf enum %s of %s",
+				field.Name, field.Message, field.GoType, strings.TrimPrefix(field.TypeName, "."), strings.TrimPrefix(po.fullName, "."), enumName)
+		}
+	}
+	return nil
+}
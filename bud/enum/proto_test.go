@@ -0,0 +1,21 @@
+package enum
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestProtoExtOptions(t *testing.T) {
+	// the items are matched by the enumgoname options, and the gotype of the field Task.state is TaskStatus
+	body, err := generateBody(t, "package enum\n\n// @EnumConfig(proto=\"github.com/peace0phmind/bud/bud/example/pb.TaskState\")\n// @ENUM{unspecified, backlog, doing, done}\ntype TaskStatus int\n")
+	assert.NoError(t, err)
+	assert.Contains(t, body, "TaskStatusBacklog: pb.TaskState_TASK_STATE_TODO,")
+	assert.Contains(t, body, "TaskStatusDoing: pb.TaskState_TASK_STATE_IN_PROGRESS,")
+
+	// the gotype of another package is not the enum
+	_, err = generateBody(t, "package other\n\n// @EnumConfig(proto=\"github.com/peace0phmind/bud/bud/example/pb.TaskPriority\")\n// @ENUM{low, high}\ntype TaskStatus int\n")
+	assert.NoError(t, err)
+
+	_, err = generateBody(t, "package enum\n\n// @EnumConfig(proto=\"github.com/peace0phmind/bud/bud/example/pb.TaskPriority\")\n// @ENUM{low, high}\ntype TaskStatus int\n")
+	assert.ErrorContains(t, err, "protobuf field Status of task.Task has gotype enum.TaskStatus, but its type task.TaskState is not the protobuf enum task.TaskPriority of TaskStatus")
+}
@@ -3,6 +3,7 @@ package enum
 import (
 	"errors"
 	"fmt"
+	"go/types"
 	"reflect"
 )
 
@@ -26,6 +27,17 @@ var enumAttributeTypes = append(enumTypes,
 	reflect.Float64,
 )
 
+// basicKind returns the kind of the underlying type of typ if it is an enum attribute type, or reflect.Invalid.
+func basicKind(typ types.Type) reflect.Kind {
+	if basic, ok := typ.Underlying().(*types.Basic); ok {
+		if k, err := getEnumAttributeKindByName(basic.Name()); err == nil {
+			return k
+		}
+	}
+
+	return reflect.Invalid
+}
+
 func getEnumKindByName(name string) (reflect.Kind, error) {
 	for _, k := range enumTypes {
 		if k.String() == name {
@@ -0,0 +1,112 @@
+package enum
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// tsEntry is a property of a TypeScript object literal, or an element of a Map.
+type tsEntry struct {
+	Key   string
+	Value string
+}
+
+// tsItem is a property of the const object of an enum, the cased name of an item and the values of its attributes.
+type tsItem struct {
+	Key        string
+	Attributes []tsEntry
+}
+
+// tsLiteral returns the TypeScript literal of a string, number or boolean.
+func tsLiteral(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("typescript literal %v: %w", v, err)
+	}
+	return string(data), nil
+}
+
+// TSDoc returns the doc of the enum in one line.
+func (e *Enum) TSDoc() string {
+	return e.docText()
+}
+
+// TSValues returns the TypeScript literals of the values the enum is encoded to, which follow ForceUpper and
+// ForceLower like the go side.
+func (e *Enum) TSValues() ([]string, error) {
+	var result []string
+	for _, item := range e.GetItems() {
+		value, err := tsLiteral(e.encodedValue(item))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// TSUnion returns the TypeScript union type of the values of the enum.
+func (e *Enum) TSUnion() (string, error) {
+	values, err := e.TSValues()
+	return strings.Join(values, " | "), err
+}
+
+// TSItems returns the properties of the const object of the enum, the cased names of the items and the values of
+// their builtin attributes. The attribute the enum is encoded to has the encoded value, and an attribute left blank
+// by an item is left out.
+func (e *Enum) TSItems() ([]tsItem, error) {
+	encoded := e.encodedAttribute()
+
+	var result []tsItem
+	for _, item := range e.GetItems() {
+		ti := tsItem{Key: item.GetCasedName()}
+		for _, attr := range e.Attrs {
+			if !attr.IsBuiltin() {
+				continue
+			}
+
+			v := attr.jsonValue(item)
+			if attr == encoded {
+				v = e.encodedValue(item)
+			}
+			if v == nil {
+				continue
+			}
+
+			value, err := tsLiteral(v)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s of %s: %w", attr.Name, item.Name, err)
+			}
+			ti.Attributes = append(ti.Attributes, tsEntry{Key: attr.Name, Value: value})
+		}
+		result = append(result, ti)
+	}
+	return result, nil
+}
+
+// TSParse returns the entries of the Map parsing the texts of an enum encoded as a text, with NoCase the lower case
+// texts are added like the go side. It returns nil if the enum is not marshalled as a text.
+func (e *Enum) TSParse() ([]tsEntry, error) {
+	if !e.Config.Marshal {
+		return nil, nil
+	}
+
+	var result []tsEntry
+	for _, item := range e.GetItems() {
+		text := fmt.Sprintf("%v", e.encodedValue(item))
+		value, err := tsLiteral(text)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tsEntry{Key: value, Value: value})
+		if e.Config.NoCase && text != strings.ToLower(text) {
+			lower, err := tsLiteral(strings.ToLower(text))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, tsEntry{Key: lower, Value: value})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,27 @@
+package enum
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTSLiteral(t *testing.T) {
+	for _, tt := range []struct {
+		value any
+		want  string
+	}{
+		{"a \"b\"", `"a \"b\""`},
+		{10, "10"},
+		{1.5, "1.5"},
+		{true, "true"},
+	} {
+		literal, err := tsLiteral(tt.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, literal)
+	}
+
+	_, err := tsLiteral(math.NaN())
+	assert.EqualError(t, err, "typescript literal NaN: json: unsupported value: NaN")
+}
@@ -0,0 +1,23 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// Permission is a set of file permissions.
+// @EnumConfig(bitmask, marshal, sql, sqlName=name, nocase, names)
+// @ENUM{
+// none = 0
+// read
+// write
+// exec
+// }
+type Permission uint8
+
+// Feature is a mask of the features of an account, stored as a number.
+// @EnumConfig(bitmask, sql, sqlName=Val)
+// @ENUM{
+// search
+// _
+// export = 8
+// audit
+// }
+type Feature int32
@@ -0,0 +1,371 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// FeatureSearch is a Feature of type search.
+	FeatureSearch Feature = 1
+	// Skipped value.
+	_
+	// FeatureExport is a Feature of type export.
+	FeatureExport Feature = 8
+	// FeatureAudit is a Feature of type audit.
+	FeatureAudit Feature = 16
+)
+const (
+	// PermissionNone is a Permission of type none.
+	PermissionNone Permission = 0
+	// PermissionRead is a Permission of type read.
+	PermissionRead Permission = 1
+	// PermissionWrite is a Permission of type write.
+	PermissionWrite Permission = 2
+	// PermissionExec is a Permission of type exec.
+	PermissionExec Permission = 4
+)
+
+var ErrInvalidFeature = errors.New("not a valid Feature")
+
+var _FeatureName = "searchexportaudit"
+
+var _FeatureMapName = map[Feature]string{
+	FeatureSearch: _FeatureName[0:6],
+	FeatureExport: _FeatureName[6:12],
+	FeatureAudit:  _FeatureName[12:17],
+}
+
+// Name is the attribute of Feature.
+func (x Feature) Name() string {
+	if v, ok := _FeatureMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Feature(%d).Name", x)
+}
+
+// Val is the attribute of Feature.
+func (x Feature) Val() int32 {
+	return int32(x)
+}
+
+var _FeatureFlags = []Feature{
+	FeatureSearch,
+	FeatureExport,
+	FeatureAudit,
+}
+
+const _FeatureMask = FeatureSearch | FeatureExport | FeatureAudit
+
+// IsValid provides a quick way to determine if the typed value is
+// a combination of the allowed enumerated values
+func (x Feature) IsValid() bool {
+	return x&^_FeatureMask == 0
+}
+
+// Has reports whether all the bits of flags are set in x.
+func (x Feature) Has(flags Feature) bool {
+	return x&flags == flags
+}
+
+// Set returns x with the bits of flags set.
+func (x Feature) Set(flags Feature) Feature {
+	return x | flags
+}
+
+// Clear returns x with the bits of flags cleared.
+func (x Feature) Clear(flags Feature) Feature {
+	return x &^ flags
+}
+
+// Toggle returns x with the bits of flags toggled.
+func (x Feature) Toggle(flags Feature) Feature {
+	return x ^ flags
+}
+
+// String implements the Stringer interface, the names of the flags of a combined value are joined by "|".
+func (x Feature) String() string {
+	if v, ok := _FeatureMapName[x]; ok {
+		return v
+	}
+
+	var names []string
+	rest := x
+	for _, flag := range _FeatureFlags {
+		if x&flag == flag {
+			names = append(names, _FeatureMapName[flag])
+			rest &^= flag
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("Feature(%d)", rest))
+	}
+	return strings.Join(names, "|")
+}
+
+var _FeatureNameMap = map[string]Feature{
+	_FeatureName[0:6]:   FeatureSearch,
+	_FeatureName[6:12]:  FeatureExport,
+	_FeatureName[12:17]: FeatureAudit,
+}
+
+// ParseFeature converts a string to a Feature, the names of combined flags are joined by "|" like "a|b".
+func ParseFeature(value string) (Feature, error) {
+	var x Feature
+	for _, name := range strings.Split(value, "|") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+
+		flag, ok := _FeatureNameMap[name]
+		if !ok {
+			return Feature(0), fmt.Errorf("%s is %w", value, ErrInvalidFeature)
+		}
+		x |= flag
+	}
+	return x, nil
+}
+
+var ErrFeatureNilPtr = errors.New("value pointer is nil")
+
+// Scan implements the Scanner interface.
+func (x *Feature) Scan(value any) (err error) {
+	if value == nil {
+		*x = Feature(0)
+		return
+	}
+
+	switch v := value.(type) {
+	case int:
+		*x = Feature(v)
+	case int64:
+		*x = Feature(v)
+	case uint:
+		*x = Feature(v)
+	case uint64:
+		*x = Feature(v)
+	case float64:
+		*x = Feature(v)
+	case *int:
+		if v == nil {
+			return ErrFeatureNilPtr
+		}
+		*x = Feature(*v)
+	case *int64:
+		if v == nil {
+			return ErrFeatureNilPtr
+		}
+		*x = Feature(*v)
+	case *uint:
+		if v == nil {
+			return ErrFeatureNilPtr
+		}
+		*x = Feature(*v)
+	case *uint64:
+		if v == nil {
+			return ErrFeatureNilPtr
+		}
+		*x = Feature(*v)
+	case *float64:
+		if v == nil {
+			return ErrFeatureNilPtr
+		}
+		*x = Feature(*v)
+	case Feature:
+		*x = v
+	case *Feature:
+		if v == nil {
+			return ErrFeatureNilPtr
+		}
+		*x = *v
+	}
+
+	if !x.IsValid() {
+		return ErrInvalidFeature
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x Feature) Value() (driver.Value, error) {
+	return x.Val(), nil
+}
+
+var ErrInvalidPermission = fmt.Errorf("not a valid Permission, try [%s]", strings.Join(_PermissionNames, ", "))
+
+var _PermissionName = "nonereadwriteexec"
+
+var _PermissionMapName = map[Permission]string{
+	PermissionNone:  _PermissionName[0:4],
+	PermissionRead:  _PermissionName[4:8],
+	PermissionWrite: _PermissionName[8:13],
+	PermissionExec:  _PermissionName[13:17],
+}
+
+// Name is the attribute of Permission.
+func (x Permission) Name() string {
+	if v, ok := _PermissionMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Permission(%d).Name", x)
+}
+
+// Val is the attribute of Permission.
+func (x Permission) Val() uint8 {
+	return uint8(x)
+}
+
+var _PermissionNames = []string{
+	_PermissionName[0:4],
+	_PermissionName[4:8],
+	_PermissionName[8:13],
+	_PermissionName[13:17],
+}
+
+// PermissionNames returns a list of the names of Permission
+func PermissionNames() []string {
+	return _PermissionNames
+}
+
+var _PermissionFlags = []Permission{
+	PermissionRead,
+	PermissionWrite,
+	PermissionExec,
+}
+
+const _PermissionMask = PermissionRead | PermissionWrite | PermissionExec
+
+// IsValid provides a quick way to determine if the typed value is
+// a combination of the allowed enumerated values
+func (x Permission) IsValid() bool {
+	return x&^_PermissionMask == 0
+}
+
+// Has reports whether all the bits of flags are set in x.
+func (x Permission) Has(flags Permission) bool {
+	return x&flags == flags
+}
+
+// Set returns x with the bits of flags set.
+func (x Permission) Set(flags Permission) Permission {
+	return x | flags
+}
+
+// Clear returns x with the bits of flags cleared.
+func (x Permission) Clear(flags Permission) Permission {
+	return x &^ flags
+}
+
+// Toggle returns x with the bits of flags toggled.
+func (x Permission) Toggle(flags Permission) Permission {
+	return x ^ flags
+}
+
+// String implements the Stringer interface, the names of the flags of a combined value are joined by "|".
+func (x Permission) String() string {
+	if v, ok := _PermissionMapName[x]; ok {
+		return v
+	}
+
+	var names []string
+	rest := x
+	for _, flag := range _PermissionFlags {
+		if x&flag == flag {
+			names = append(names, _PermissionMapName[flag])
+			rest &^= flag
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("Permission(%d)", rest))
+	}
+	return strings.Join(names, "|")
+}
+
+var _PermissionNameMap = map[string]Permission{
+	_PermissionName[0:4]:   PermissionNone,
+	_PermissionName[4:8]:   PermissionRead,
+	_PermissionName[8:13]:  PermissionWrite,
+	_PermissionName[13:17]: PermissionExec,
+}
+
+// ParsePermission converts a string to a Permission, the names of combined flags are joined by "|" like "a|b".
+func ParsePermission(value string) (Permission, error) {
+	var x Permission
+	for _, name := range strings.Split(value, "|") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+
+		flag, ok := _PermissionNameMap[name]
+		if !ok {
+			flag, ok = _PermissionNameMap[strings.ToLower(name)]
+		}
+		if !ok {
+			return Permission(0), fmt.Errorf("%s is %w", value, ErrInvalidPermission)
+		}
+		x |= flag
+	}
+	return x, nil
+}
+
+// MarshalText implements the text marshaller method.
+func (x Permission) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *Permission) UnmarshalText(text []byte) error {
+	val, err := ParsePermission(string(text))
+	if err != nil {
+		return err
+	}
+	*x = val
+	return nil
+}
+
+var ErrPermissionNilPtr = errors.New("value pointer is nil")
+
+// Scan implements the Scanner interface, the names of combined flags are joined by "|".
+func (x *Permission) Scan(value any) (err error) {
+	if value == nil {
+		*x = Permission(0)
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		*x, err = ParsePermission(v)
+	case []byte:
+		*x, err = ParsePermission(string(v))
+	case *string:
+		if v == nil {
+			return ErrPermissionNilPtr
+		}
+		*x, err = ParsePermission(*v)
+	case Permission:
+		*x = v
+	case *Permission:
+		if v == nil {
+			return ErrPermissionNilPtr
+		}
+		*x = *v
+	default:
+		return fmt.Errorf("%v is %w", value, ErrInvalidPermission)
+	}
+
+	if err == nil && !x.IsValid() {
+		return ErrInvalidPermission
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x Permission) Value() (driver.Value, error) {
+	return x.String(), nil
+}
@@ -0,0 +1,62 @@
+package enum
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionBitmask(t *testing.T) {
+	p := PermissionRead.Set(PermissionWrite)
+	assert.True(t, p.Has(PermissionRead|PermissionWrite))
+	assert.False(t, p.Has(PermissionExec))
+	assert.True(t, p.Has(PermissionNone))
+	assert.Equal(t, PermissionWrite, p.Clear(PermissionRead))
+	assert.Equal(t, PermissionWrite|PermissionExec, p.Toggle(PermissionRead|PermissionExec))
+
+	assert.Equal(t, "read|write", p.String())
+	assert.Equal(t, "none", PermissionNone.String())
+	assert.Equal(t, "exec|Permission(8)", Permission(12).String())
+	assert.True(t, p.IsValid())
+	assert.False(t, Permission(8).IsValid())
+
+	parsed, err := ParsePermission("Read | exec")
+	assert.NoError(t, err)
+	assert.Equal(t, PermissionRead|PermissionExec, parsed)
+	_, err = ParsePermission("read|delete")
+	assert.ErrorIs(t, err, ErrInvalidPermission)
+
+	data, err := json.Marshal(struct{ P Permission }{PermissionRead | PermissionExec})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"P":"read|exec"}`, string(data))
+	var s struct{ P Permission }
+	assert.NoError(t, json.Unmarshal([]byte(`{"P":"write|exec"}`), &s))
+	assert.Equal(t, PermissionWrite|PermissionExec, s.P)
+
+	value, err := p.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "read|write", value)
+	var scanned Permission
+	assert.NoError(t, scanned.Scan([]byte("write|exec")))
+	assert.Equal(t, PermissionWrite|PermissionExec, scanned)
+	assert.Error(t, scanned.Scan(3))
+}
+
+func TestFeatureBitmask(t *testing.T) {
+	assert.Equal(t, Feature(1), FeatureSearch)
+	assert.Equal(t, Feature(8), FeatureExport)
+	assert.Equal(t, Feature(16), FeatureAudit)
+	assert.Equal(t, "", Feature(0).String())
+
+	f, err := ParseFeature("search|audit")
+	assert.NoError(t, err)
+	value, err := f.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(17), value)
+
+	var scanned Feature
+	assert.NoError(t, scanned.Scan(int64(9)))
+	assert.Equal(t, FeatureSearch|FeatureExport, scanned)
+	assert.ErrorIs(t, scanned.Scan(int64(2)), ErrInvalidFeature)
+}
@@ -0,0 +1,20 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// @EnumConfig(sql, gorm)
+// @ENUM{pending, paid, shipped}
+type PaymentStatus int
+
+// @EnumConfig(sql, gorm)
+// @ENUM{pending, paid, shipped}
+type PaymentStrStatus string
+
+// @EnumConfig(sql, gorm, sqlName=code)
+//
+//	@ENUM(code int) {
+//		pending(0)
+//		paid(10)
+//		shipped(20)
+//	}
+type PaymentStatusCode string
@@ -0,0 +1,413 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// PaymentStatusPending is a PaymentStatus of type pending.
+	PaymentStatusPending PaymentStatus = iota
+	// PaymentStatusPaid is a PaymentStatus of type paid.
+	PaymentStatusPaid
+	// PaymentStatusShipped is a PaymentStatus of type shipped.
+	PaymentStatusShipped
+)
+const (
+	// PaymentStatusCodePending is a PaymentStatusCode of type pending.
+	PaymentStatusCodePending PaymentStatusCode = "pending"
+	// PaymentStatusCodePaid is a PaymentStatusCode of type paid.
+	PaymentStatusCodePaid PaymentStatusCode = "paid"
+	// PaymentStatusCodeShipped is a PaymentStatusCode of type shipped.
+	PaymentStatusCodeShipped PaymentStatusCode = "shipped"
+)
+const (
+	// PaymentStrStatusPending is a PaymentStrStatus of type pending.
+	PaymentStrStatusPending PaymentStrStatus = "pending"
+	// PaymentStrStatusPaid is a PaymentStrStatus of type paid.
+	PaymentStrStatusPaid PaymentStrStatus = "paid"
+	// PaymentStrStatusShipped is a PaymentStrStatus of type shipped.
+	PaymentStrStatusShipped PaymentStrStatus = "shipped"
+)
+
+var ErrInvalidPaymentStatus = errors.New("not a valid PaymentStatus")
+
+var _PaymentStatusName = "pendingpaidshipped"
+
+var _PaymentStatusMapName = map[PaymentStatus]string{
+	PaymentStatusPending: _PaymentStatusName[0:7],
+	PaymentStatusPaid:    _PaymentStatusName[7:11],
+	PaymentStatusShipped: _PaymentStatusName[11:18],
+}
+
+// Name is the attribute of PaymentStatus.
+func (x PaymentStatus) Name() string {
+	if v, ok := _PaymentStatusMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("PaymentStatus(%d).Name", x)
+}
+
+// Val is the attribute of PaymentStatus.
+func (x PaymentStatus) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x PaymentStatus) IsValid() bool {
+	_, ok := _PaymentStatusMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x PaymentStatus) String() string {
+	return x.Name()
+}
+
+var _PaymentStatusNameMap = map[string]PaymentStatus{
+	_PaymentStatusName[0:7]:   PaymentStatusPending,
+	_PaymentStatusName[7:11]:  PaymentStatusPaid,
+	_PaymentStatusName[11:18]: PaymentStatusShipped,
+}
+
+// ParsePaymentStatus converts a string to a PaymentStatus.
+func ParsePaymentStatus(value string) (PaymentStatus, error) {
+	if x, ok := _PaymentStatusNameMap[value]; ok {
+		return x, nil
+	}
+	return PaymentStatus(0), fmt.Errorf("%s is %w", value, ErrInvalidPaymentStatus)
+}
+
+var ErrPaymentStatusNilPtr = errors.New("value pointer is nil")
+
+// Scan implements the Scanner interface.
+func (x *PaymentStatus) Scan(value any) (err error) {
+	if value == nil {
+		*x = PaymentStatus(0)
+		return
+	}
+
+	switch v := value.(type) {
+	case int:
+		*x = PaymentStatus(v)
+	case int64:
+		*x = PaymentStatus(v)
+	case uint:
+		*x = PaymentStatus(v)
+	case uint64:
+		*x = PaymentStatus(v)
+	case float64:
+		*x = PaymentStatus(v)
+	case *int:
+		if v == nil {
+			return ErrPaymentStatusNilPtr
+		}
+		*x = PaymentStatus(*v)
+	case *int64:
+		if v == nil {
+			return ErrPaymentStatusNilPtr
+		}
+		*x = PaymentStatus(*v)
+	case *uint:
+		if v == nil {
+			return ErrPaymentStatusNilPtr
+		}
+		*x = PaymentStatus(*v)
+	case *uint64:
+		if v == nil {
+			return ErrPaymentStatusNilPtr
+		}
+		*x = PaymentStatus(*v)
+	case *float64:
+		if v == nil {
+			return ErrPaymentStatusNilPtr
+		}
+		*x = PaymentStatus(*v)
+	case PaymentStatus:
+		*x = v
+	case *PaymentStatus:
+		if v == nil {
+			return ErrPaymentStatusNilPtr
+		}
+		*x = *v
+	}
+
+	if !x.IsValid() {
+		return ErrInvalidPaymentStatus
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x PaymentStatus) Value() (driver.Value, error) {
+	return x.Val(), nil
+}
+
+// GormDataType implements the gorm GormDataTypeInterface.
+func (x PaymentStatus) GormDataType() string {
+	return "int"
+}
+
+// GormDBDataType implements the gorm GormDBDataTypeInterface, the column only accepts the stored values of
+// PaymentStatus, with a CHECK constraint.
+func (x PaymentStatus) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if field.Size == 0 {
+		sized := *field
+		sized.Size = 64
+		field = &sized
+	}
+	var column strings.Builder
+	db.Dialector.QuoteTo(&column, field.DBName)
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", db.Dialector.DataTypeOf(field), column.String(), "0, 1, 2")
+}
+
+var ErrInvalidPaymentStatusCode = errors.New("not a valid PaymentStatusCode")
+
+var _PaymentStatusCodeNameMap = map[string]PaymentStatusCode{
+	"pending": PaymentStatusCodePending,
+	"paid":    PaymentStatusCodePaid,
+	"shipped": PaymentStatusCodeShipped,
+}
+
+// Name is the attribute of PaymentStatusCode.
+func (x PaymentStatusCode) Name() string {
+	if v, ok := _PaymentStatusCodeNameMap[string(x)]; ok {
+		return string(v)
+	}
+	return fmt.Sprintf("PaymentStatusCode(%s).Name", string(x))
+}
+
+var _PaymentStatusCodeMapCode = map[PaymentStatusCode]int{
+	PaymentStatusCodePending: 0,
+	PaymentStatusCodePaid:    10,
+	PaymentStatusCodeShipped: 20,
+}
+
+// Code is the attribute of PaymentStatusCode.
+func (x PaymentStatusCode) Code() int {
+	if v, ok := _PaymentStatusCodeMapCode[x]; ok {
+		return v
+	}
+	return 0
+}
+
+// Val is the attribute of PaymentStatusCode.
+func (x PaymentStatusCode) Val() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x PaymentStatusCode) IsValid() bool {
+	_, ok := _PaymentStatusCodeNameMap[string(x)]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x PaymentStatusCode) String() string {
+	return x.Name()
+}
+
+// ParsePaymentStatusCode converts a string to a PaymentStatusCode.
+func ParsePaymentStatusCode(value string) (PaymentStatusCode, error) {
+	if x, ok := _PaymentStatusCodeNameMap[value]; ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("%s is %w", value, ErrInvalidPaymentStatusCode)
+}
+
+var ErrPaymentStatusCodeNilPtr = errors.New("value pointer is nil")
+
+var _PaymentStatusCodeCodeMap = map[int]PaymentStatusCode{
+	0:  PaymentStatusCodePending,
+	10: PaymentStatusCodePaid,
+	20: PaymentStatusCodeShipped,
+}
+
+// Scan implements the Scanner interface.
+func (x *PaymentStatusCode) Scan(value any) (err error) {
+	if value == nil {
+		*x = ""
+		return
+	}
+
+	var ok bool
+	switch v := value.(type) {
+	case int:
+		*x, ok = _PaymentStatusCodeCodeMap[v]
+	case int64:
+		*x, ok = _PaymentStatusCodeCodeMap[int(v)]
+	case uint:
+		*x, ok = _PaymentStatusCodeCodeMap[int(v)]
+	case uint64:
+		*x, ok = _PaymentStatusCodeCodeMap[int(v)]
+	case float64:
+		*x, ok = _PaymentStatusCodeCodeMap[int(v)]
+	case *int:
+		if v == nil {
+			return ErrPaymentStatusCodeNilPtr
+		}
+		*x, ok = _PaymentStatusCodeCodeMap[*v]
+	case *int64:
+		if v == nil {
+			return ErrPaymentStatusCodeNilPtr
+		}
+		*x, ok = _PaymentStatusCodeCodeMap[int(*v)]
+	case *uint:
+		if v == nil {
+			return ErrPaymentStatusCodeNilPtr
+		}
+		*x, ok = _PaymentStatusCodeCodeMap[int(*v)]
+	case *uint64:
+		if v == nil {
+			return ErrPaymentStatusCodeNilPtr
+		}
+		*x, ok = _PaymentStatusCodeCodeMap[int(*v)]
+	case *float64:
+		if v == nil {
+			return ErrPaymentStatusCodeNilPtr
+		}
+		*x, ok = _PaymentStatusCodeCodeMap[int(*v)]
+	case PaymentStatusCode:
+		*x = v
+		ok = x.IsValid()
+	case *PaymentStatusCode:
+		if v == nil {
+			return ErrPaymentStatusCodeNilPtr
+		}
+		*x = *v
+		ok = x.IsValid()
+	}
+
+	if !ok {
+		return ErrInvalidPaymentStatusCode
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x PaymentStatusCode) Value() (driver.Value, error) {
+	return x.Code(), nil
+}
+
+// GormDataType implements the gorm GormDataTypeInterface.
+func (x PaymentStatusCode) GormDataType() string {
+	return "int"
+}
+
+// GormDBDataType implements the gorm GormDBDataTypeInterface, the column only accepts the stored values of
+// PaymentStatusCode, with a CHECK constraint.
+func (x PaymentStatusCode) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if field.Size == 0 {
+		sized := *field
+		sized.Size = 64
+		field = &sized
+	}
+	var column strings.Builder
+	db.Dialector.QuoteTo(&column, field.DBName)
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", db.Dialector.DataTypeOf(field), column.String(), "0, 10, 20")
+}
+
+var ErrInvalidPaymentStrStatus = errors.New("not a valid PaymentStrStatus")
+
+var _PaymentStrStatusNameMap = map[string]PaymentStrStatus{
+	"pending": PaymentStrStatusPending,
+	"paid":    PaymentStrStatusPaid,
+	"shipped": PaymentStrStatusShipped,
+}
+
+// Name is the attribute of PaymentStrStatus.
+func (x PaymentStrStatus) Name() string {
+	if v, ok := _PaymentStrStatusNameMap[string(x)]; ok {
+		return string(v)
+	}
+	return fmt.Sprintf("PaymentStrStatus(%s).Name", string(x))
+}
+
+// Val is the attribute of PaymentStrStatus.
+func (x PaymentStrStatus) Val() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x PaymentStrStatus) IsValid() bool {
+	_, ok := _PaymentStrStatusNameMap[string(x)]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x PaymentStrStatus) String() string {
+	return x.Name()
+}
+
+// ParsePaymentStrStatus converts a string to a PaymentStrStatus.
+func ParsePaymentStrStatus(value string) (PaymentStrStatus, error) {
+	if x, ok := _PaymentStrStatusNameMap[value]; ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("%s is %w", value, ErrInvalidPaymentStrStatus)
+}
+
+var ErrPaymentStrStatusNilPtr = errors.New("value pointer is nil")
+
+// Scan implements the Scanner interface.
+func (x *PaymentStrStatus) Scan(value any) (err error) {
+	if value == nil {
+		*x = ""
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		*x = PaymentStrStatus(v)
+	case []byte:
+		*x = PaymentStrStatus(string(v))
+	case *string:
+		if v == nil {
+			return ErrPaymentStrStatusNilPtr
+		}
+		*x = PaymentStrStatus(*v)
+	case PaymentStrStatus:
+		*x = v
+	case *PaymentStrStatus:
+		if v == nil {
+			return ErrPaymentStrStatusNilPtr
+		}
+		*x = *v
+	}
+
+	if !x.IsValid() {
+		return ErrInvalidPaymentStrStatus
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x PaymentStrStatus) Value() (driver.Value, error) {
+	return x.Val(), nil
+}
+
+// GormDataType implements the gorm GormDataTypeInterface.
+func (x PaymentStrStatus) GormDataType() string {
+	return "string"
+}
+
+// GormDBDataType implements the gorm GormDBDataTypeInterface, the column only accepts the stored values of
+// PaymentStrStatus, as an ENUM in MySQL and with a CHECK constraint in the other databases.
+func (x PaymentStrStatus) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "mysql" {
+		return "ENUM('pending', 'paid', 'shipped')"
+	}
+	var column strings.Builder
+	db.Dialector.QuoteTo(&column, field.DBName)
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", db.Dialector.DataTypeOf(field), column.String(), "'pending', 'paid', 'shipped'")
+}
@@ -0,0 +1,33 @@
+package enum
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func TestGormDBDataType(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "user:pass@tcp(127.0.0.1:3306)/db", SkipInitializeWithVersion: true}),
+		&gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	type payment struct {
+		Status    PaymentStatus
+		StrStatus PaymentStrStatus
+		Code      PaymentStatusCode
+	}
+	s, err := schema.Parse(&payment{}, &sync.Map{}, db.NamingStrategy)
+	assert.NoError(t, err)
+
+	dataType := func(name string) string {
+		return db.Migrator().FullDataTypeOf(s.LookUpField(name)).SQL
+	}
+	assert.Equal(t, "bigint CHECK (`status` IN (0, 1, 2))", dataType("Status"))
+	assert.Equal(t, "ENUM('pending', 'paid', 'shipped')", dataType("StrStatus"))
+	assert.Equal(t, "bigint CHECK (`code` IN (0, 10, 20))", dataType("Code"))
+	assert.Equal(t, "string", PaymentStrStatus("").GormDataType())
+}
@@ -0,0 +1,18 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// Priority is the priority of a ticket.
+// @EnumConfig(marshal, jsonSchema)
+// @ENUM(Name string, Weight int, Urgent bool){
+// low(_, 1, false)   // can wait for the next release
+// // handled in the current sprint
+// medium(_, 5, false)
+// high(_, 10, true)  // fixed immediately
+// }
+type Priority int
+
+// Level is stored as its value.
+// @EnumConfig(jsonSchema)
+// @ENUM{debug, info, warn = 4, error}
+type Level uint8
@@ -0,0 +1,253 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/peace0phmind/bud/jsonschema"
+)
+
+const (
+	// LevelDebug is a Level of type debug.
+	LevelDebug Level = 0
+	// LevelInfo is a Level of type info.
+	LevelInfo Level = 1
+	// LevelWarn is a Level of type warn.
+	LevelWarn Level = 4
+	// LevelError is a Level of type error.
+	LevelError Level = 5
+)
+const (
+	// PriorityLow is a Priority of type low.
+	PriorityLow Priority = iota // can wait for the next release
+	// PriorityMedium is a Priority of type medium.
+	// handled in the current sprint
+	PriorityMedium
+	// PriorityHigh is a Priority of type high.
+	PriorityHigh // fixed immediately
+)
+
+func init() {
+	jsonschema.Register[Level](LevelJSONSchema())
+}
+
+func init() {
+	jsonschema.Register[Priority](PriorityJSONSchema())
+}
+
+var ErrInvalidLevel = errors.New("not a valid Level")
+
+var _LevelName = "debuginfowarnerror"
+
+var _LevelMapName = map[Level]string{
+	LevelDebug: _LevelName[0:5],
+	LevelInfo:  _LevelName[5:9],
+	LevelWarn:  _LevelName[9:13],
+	LevelError: _LevelName[13:18],
+}
+
+// Name is the attribute of Level.
+func (x Level) Name() string {
+	if v, ok := _LevelMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Level(%d).Name", x)
+}
+
+// Val is the attribute of Level.
+func (x Level) Val() uint8 {
+	return uint8(x)
+}
+
+const _LevelJSONSchema = `{
+  "title": "Level",
+  "description": "Level is stored as its value.",
+  "type": "integer",
+  "enum": [
+    0,
+    1,
+    4,
+    5
+  ],
+  "oneOf": [
+    {
+      "const": 0,
+      "x-name": "debug"
+    },
+    {
+      "const": 1,
+      "x-name": "info"
+    },
+    {
+      "const": 4,
+      "x-name": "warn"
+    },
+    {
+      "const": 5,
+      "x-name": "error"
+    }
+  ]
+}`
+
+// LevelJSONSchema returns the JSON schema of the values Level is encoded to, it is registered in package jsonschema.
+func LevelJSONSchema() json.RawMessage {
+	return json.RawMessage(_LevelJSONSchema)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Level) IsValid() bool {
+	_, ok := _LevelMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Level) String() string {
+	return x.Name()
+}
+
+var _LevelNameMap = map[string]Level{
+	_LevelName[0:5]:   LevelDebug,
+	_LevelName[5:9]:   LevelInfo,
+	_LevelName[9:13]:  LevelWarn,
+	_LevelName[13:18]: LevelError,
+}
+
+// ParseLevel converts a string to a Level.
+func ParseLevel(value string) (Level, error) {
+	if x, ok := _LevelNameMap[value]; ok {
+		return x, nil
+	}
+	return Level(0), fmt.Errorf("%s is %w", value, ErrInvalidLevel)
+}
+
+var ErrInvalidPriority = errors.New("not a valid Priority")
+
+var _PriorityName = "lowmediumhigh"
+
+var _PriorityMapName = map[Priority]string{
+	PriorityLow:    _PriorityName[0:3],
+	PriorityMedium: _PriorityName[3:9],
+	PriorityHigh:   _PriorityName[9:13],
+}
+
+// Name is the attribute of Priority.
+func (x Priority) Name() string {
+	if v, ok := _PriorityMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Priority(%d).Name", x)
+}
+
+var _PriorityMapWeight = map[Priority]int{
+	PriorityLow:    1,
+	PriorityMedium: 5,
+	PriorityHigh:   10,
+}
+
+// Weight is the attribute of Priority.
+func (x Priority) Weight() int {
+	if v, ok := _PriorityMapWeight[x]; ok {
+		return v
+	}
+	return 0
+}
+
+var _PriorityMapUrgent = map[Priority]bool{
+	PriorityLow:    false,
+	PriorityMedium: false,
+	PriorityHigh:   true,
+}
+
+// Urgent is the attribute of Priority.
+func (x Priority) Urgent() bool {
+	if v, ok := _PriorityMapUrgent[x]; ok {
+		return v
+	}
+	return false
+}
+
+// Val is the attribute of Priority.
+func (x Priority) Val() int {
+	return int(x)
+}
+
+const _PriorityJSONSchema = `{
+  "title": "Priority",
+  "description": "Priority is the priority of a ticket.",
+  "type": "string",
+  "enum": [
+    "low",
+    "medium",
+    "high"
+  ],
+  "oneOf": [
+    {
+      "const": "low",
+      "description": "can wait for the next release",
+      "x-urgent": false,
+      "x-weight": 1
+    },
+    {
+      "const": "medium",
+      "description": "handled in the current sprint",
+      "x-urgent": false,
+      "x-weight": 5
+    },
+    {
+      "const": "high",
+      "description": "fixed immediately",
+      "x-urgent": true,
+      "x-weight": 10
+    }
+  ]
+}`
+
+// PriorityJSONSchema returns the JSON schema of the values Priority is encoded to, it is registered in package jsonschema.
+func PriorityJSONSchema() json.RawMessage {
+	return json.RawMessage(_PriorityJSONSchema)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Priority) IsValid() bool {
+	_, ok := _PriorityMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Priority) String() string {
+	return x.Name()
+}
+
+var _PriorityNameMap = map[string]Priority{
+	_PriorityName[0:3]:  PriorityLow,
+	_PriorityName[3:9]:  PriorityMedium,
+	_PriorityName[9:13]: PriorityHigh,
+}
+
+// ParsePriority converts a string to a Priority.
+func ParsePriority(value string) (Priority, error) {
+	if x, ok := _PriorityNameMap[value]; ok {
+		return x, nil
+	}
+	return Priority(0), fmt.Errorf("%s is %w", value, ErrInvalidPriority)
+}
+
+// MarshalText implements the text marshaller method.
+func (x Priority) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *Priority) UnmarshalText(text []byte) error {
+	val, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*x = val
+	return nil
+}
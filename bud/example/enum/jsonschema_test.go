@@ -0,0 +1,52 @@
+package enum
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/peace0phmind/bud/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type enumSchema struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Type        string           `json:"type"`
+	Enum        []any            `json:"enum"`
+	OneOf       []map[string]any `json:"oneOf"`
+}
+
+func TestPriorityJSONSchema(t *testing.T) {
+	var schema enumSchema
+	require.NoError(t, json.Unmarshal(PriorityJSONSchema(), &schema))
+	assert.Equal(t, "Priority", schema.Title)
+	assert.Equal(t, "Priority is the priority of a ticket.", schema.Description)
+	assert.Equal(t, "string", schema.Type)
+	assert.Equal(t, map[string]any{"const": "medium", "description": "handled in the current sprint", "x-weight": 5.0, "x-urgent": false}, schema.OneOf[1])
+
+	// the schema values are the values the enum is encoded to
+	var encoded []any
+	data, err := json.Marshal([]Priority{PriorityLow, PriorityMedium, PriorityHigh})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &encoded))
+	assert.Equal(t, schema.Enum, encoded)
+}
+
+func TestLevelJSONSchema(t *testing.T) {
+	var schema enumSchema
+	require.NoError(t, json.Unmarshal(LevelJSONSchema(), &schema))
+	assert.Equal(t, "integer", schema.Type)
+
+	var encoded []any
+	data, err := json.Marshal([]any{LevelDebug, LevelInfo, LevelWarn, LevelError})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &encoded))
+	assert.Equal(t, schema.Enum, encoded)
+}
+
+func TestJSONSchemaRegistered(t *testing.T) {
+	schemas := jsonschema.Package("github.com/peace0phmind/bud/bud/example/enum")
+	assert.JSONEq(t, string(PriorityJSONSchema()), string(schemas["Priority"]))
+	assert.JSONEq(t, string(LevelJSONSchema()), string(jsonschema.Of[Level]()))
+}
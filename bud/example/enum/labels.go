@@ -0,0 +1,13 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// OrderStatus is the status of an order, its labels are shown in the UI.
+// @EnumConfig(marshal, labels={en: LabelEn, zh: LabelZh, "zh-TW": LabelZhTw}, labelLang=en)
+// @ENUM(LabelEn string, LabelZh string, LabelZhTw string){
+// created("Created", "已创建", "已建立")
+// paid("Paid", "已支付", "已付款")
+// shipped("Shipped", "已发货", _)
+// closed(_, _, _)
+// }
+type OrderStatus int
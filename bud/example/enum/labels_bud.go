@@ -0,0 +1,184 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// OrderStatusCreated is an OrderStatus of type created.
+	OrderStatusCreated OrderStatus = iota
+	// OrderStatusPaid is an OrderStatus of type paid.
+	OrderStatusPaid
+	// OrderStatusShipped is an OrderStatus of type shipped.
+	OrderStatusShipped
+	// OrderStatusClosed is an OrderStatus of type closed.
+	OrderStatusClosed
+)
+
+var ErrInvalidOrderStatus = errors.New("not a valid OrderStatus")
+
+var _OrderStatusName = "createdpaidshippedclosed"
+
+var _OrderStatusMapName = map[OrderStatus]string{
+	OrderStatusCreated: _OrderStatusName[0:7],
+	OrderStatusPaid:    _OrderStatusName[7:11],
+	OrderStatusShipped: _OrderStatusName[11:18],
+	OrderStatusClosed:  _OrderStatusName[18:24],
+}
+
+// Name is the attribute of OrderStatus.
+func (x OrderStatus) Name() string {
+	if v, ok := _OrderStatusMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("OrderStatus(%d).Name", x)
+}
+
+var _OrderStatusMapLabelEn = map[OrderStatus]string{
+	OrderStatusCreated: "Created",
+	OrderStatusPaid:    "Paid",
+	OrderStatusShipped: "Shipped",
+	OrderStatusClosed:  "_",
+}
+
+// LabelEn is the attribute of OrderStatus.
+func (x OrderStatus) LabelEn() string {
+	if v, ok := _OrderStatusMapLabelEn[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("OrderStatus(%d).LabelEn", x)
+}
+
+var _OrderStatusMapLabelZh = map[OrderStatus]string{
+	OrderStatusCreated: "已创建",
+	OrderStatusPaid:    "已支付",
+	OrderStatusShipped: "已发货",
+	OrderStatusClosed:  "_",
+}
+
+// LabelZh is the attribute of OrderStatus.
+func (x OrderStatus) LabelZh() string {
+	if v, ok := _OrderStatusMapLabelZh[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("OrderStatus(%d).LabelZh", x)
+}
+
+var _OrderStatusMapLabelZhTw = map[OrderStatus]string{
+	OrderStatusCreated: "已建立",
+	OrderStatusPaid:    "已付款",
+	OrderStatusShipped: "_",
+	OrderStatusClosed:  "_",
+}
+
+// LabelZhTw is the attribute of OrderStatus.
+func (x OrderStatus) LabelZhTw() string {
+	if v, ok := _OrderStatusMapLabelZhTw[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("OrderStatus(%d).LabelZhTw", x)
+}
+
+// Val is the attribute of OrderStatus.
+func (x OrderStatus) Val() int {
+	return int(x)
+}
+
+var _OrderStatusLabels = map[string]map[OrderStatus]string{
+	"en": {
+		OrderStatusCreated: "Created",
+		OrderStatusPaid:    "Paid",
+		OrderStatusShipped: "Shipped",
+	},
+	"zh": {
+		OrderStatusCreated: "已创建",
+		OrderStatusPaid:    "已支付",
+		OrderStatusShipped: "已发货",
+	},
+	"zh-tw": {
+		OrderStatusCreated: "已建立",
+		OrderStatusPaid:    "已付款",
+	},
+}
+
+// Label returns the label of x in the language lang like zh-TW. Without a label in lang, it falls back to the parent
+// languages like zh, then to the language en, and then to the name of x.
+func (x OrderStatus) Label(lang string) string {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	for {
+		if label, ok := _OrderStatusLabels[lang][x]; ok {
+			return label
+		}
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	if label, ok := _OrderStatusLabels["en"][x]; ok {
+		return label
+	}
+	return x.Name()
+}
+
+// OrderStatusOption is a value of OrderStatus with its label, like an option of a dropdown.
+type OrderStatusOption struct {
+	Value OrderStatus `json:"value"`
+	Label string      `json:"label"`
+}
+
+// OrderStatusOptions returns the values of OrderStatus with their labels in the language lang in declaration order.
+func OrderStatusOptions(lang string) []OrderStatusOption {
+	return []OrderStatusOption{
+		{Value: OrderStatusCreated, Label: OrderStatusCreated.Label(lang)},
+		{Value: OrderStatusPaid, Label: OrderStatusPaid.Label(lang)},
+		{Value: OrderStatusShipped, Label: OrderStatusShipped.Label(lang)},
+		{Value: OrderStatusClosed, Label: OrderStatusClosed.Label(lang)},
+	}
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x OrderStatus) IsValid() bool {
+	_, ok := _OrderStatusMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x OrderStatus) String() string {
+	return x.Name()
+}
+
+var _OrderStatusNameMap = map[string]OrderStatus{
+	_OrderStatusName[0:7]:   OrderStatusCreated,
+	_OrderStatusName[7:11]:  OrderStatusPaid,
+	_OrderStatusName[11:18]: OrderStatusShipped,
+	_OrderStatusName[18:24]: OrderStatusClosed,
+}
+
+// ParseOrderStatus converts a string to an OrderStatus.
+func ParseOrderStatus(value string) (OrderStatus, error) {
+	if x, ok := _OrderStatusNameMap[value]; ok {
+		return x, nil
+	}
+	return OrderStatus(0), fmt.Errorf("%s is %w", value, ErrInvalidOrderStatus)
+}
+
+// MarshalText implements the text marshaller method.
+func (x OrderStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *OrderStatus) UnmarshalText(text []byte) error {
+	val, err := ParseOrderStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*x = val
+	return nil
+}
@@ -0,0 +1,32 @@
+package enum
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderStatusLabel(t *testing.T) {
+	assert.Equal(t, "已建立", OrderStatusCreated.Label("zh-TW"))
+	assert.Equal(t, "已建立", OrderStatusCreated.Label("zh_tw"))
+	assert.Equal(t, "已发货", OrderStatusShipped.Label("zh-TW"))
+	assert.Equal(t, "已支付", OrderStatusPaid.Label("zh-Hans-CN"))
+	assert.Equal(t, "Paid", OrderStatusPaid.Label("fr"))
+	assert.Equal(t, "Paid", OrderStatusPaid.Label(""))
+	assert.Equal(t, "closed", OrderStatusClosed.Label("zh"))
+}
+
+func TestOrderStatusOptions(t *testing.T) {
+	options := OrderStatusOptions("zh-TW")
+	assert.Equal(t, []OrderStatusOption{
+		{Value: OrderStatusCreated, Label: "已建立"},
+		{Value: OrderStatusPaid, Label: "已付款"},
+		{Value: OrderStatusShipped, Label: "已发货"},
+		{Value: OrderStatusClosed, Label: "closed"},
+	}, options)
+
+	data, err := json.Marshal(options[:1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"value": "created", "label": "已建立"}]`, string(data))
+}
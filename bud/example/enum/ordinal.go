@@ -0,0 +1,15 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// Weekday is a working day, the blank item and the gap before wednesday are not counted by the ordinal.
+// @EnumConfig(ordinal)
+// @ENUM{
+// monday
+// tuesday
+// _
+// wednesday = 10
+// thursday
+// friday
+// }
+type Weekday int
@@ -0,0 +1,44 @@
+package enum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekdayOrdinal(t *testing.T) {
+	// the blank item and the gap before wednesday are not counted
+	assert.Equal(t, 0, WeekdayMonday.Ordinal())
+	assert.Equal(t, 2, WeekdayWednesday.Ordinal())
+	assert.Equal(t, 4, WeekdayFriday.Ordinal())
+	assert.Equal(t, -1, Weekday(2).Ordinal())
+
+	next, ok := WeekdayTuesday.Next()
+	assert.True(t, ok)
+	assert.Equal(t, WeekdayWednesday, next)
+	_, ok = WeekdayFriday.Next()
+	assert.False(t, ok)
+
+	prev, ok := WeekdayWednesday.Prev()
+	assert.True(t, ok)
+	assert.Equal(t, WeekdayTuesday, prev)
+	_, ok = WeekdayMonday.Prev()
+	assert.False(t, ok)
+	_, ok = Weekday(2).Prev()
+	assert.False(t, ok)
+
+	assert.Equal(t, -1, WeekdayTuesday.Compare(WeekdayWednesday))
+	assert.Equal(t, 0, WeekdayThursday.Compare(WeekdayThursday))
+	assert.Equal(t, 1, WeekdayFriday.Compare(WeekdayMonday))
+	assert.Equal(t, -1, Weekday(2).Compare(WeekdayMonday))
+
+	assert.Equal(t, WeekdayMonday, WeekdayFirst())
+	assert.Equal(t, WeekdayFriday, WeekdayLast())
+
+	var days []Weekday
+	WeekdayRange(func(d Weekday) bool {
+		days = append(days, d)
+		return d != WeekdayWednesday
+	})
+	assert.Equal(t, []Weekday{WeekdayMonday, WeekdayTuesday, WeekdayWednesday}, days)
+}
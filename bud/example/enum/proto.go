@@ -0,0 +1,24 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// Syntax is the syntax of a protobuf file, its items are the values of typepb.Syntax by name.
+// @EnumConfig(proto="google.golang.org/protobuf/types/known/typepb.Syntax")
+// @ENUM{proto2, proto3, editions}
+type Syntax int
+
+// Cardinality of a field, the Label attribute names the values of the nested protobuf enum.
+// @EnumConfig(proto="google.golang.org/protobuf/types/descriptorpb.FieldDescriptorProto_Label", protoName=Label)
+// @ENUM(Label string){
+// single(LABEL_OPTIONAL)
+// many(LABEL_REPEATED)
+// required(LABEL_REQUIRED)
+// unknown(_)
+// }
+type Cardinality string
+
+// TaskStatus is the status of a task, backlog and doing are the enumgoname options of the values TASK_STATE_TODO and
+// TASK_STATE_IN_PROGRESS in pb/task.proto.
+// @EnumConfig(proto="github.com/peace0phmind/bud/bud/example/pb.TaskState")
+// @ENUM{unspecified, backlog, doing, done}
+type TaskStatus int
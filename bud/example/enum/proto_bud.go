@@ -0,0 +1,337 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/peace0phmind/bud/bud/example/pb"
+	"github.com/peace0phmind/bud/structure"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+const (
+	// CardinalitySingle is a Cardinality of type single.
+	CardinalitySingle Cardinality = "single"
+	// CardinalityMany is a Cardinality of type many.
+	CardinalityMany Cardinality = "many"
+	// CardinalityRequired is a Cardinality of type required.
+	CardinalityRequired Cardinality = "required"
+	// CardinalityUnknown is a Cardinality of type unknown.
+	CardinalityUnknown Cardinality = "unknown"
+)
+const (
+	// SyntaxProto2 is a Syntax of type proto2.
+	SyntaxProto2 Syntax = iota
+	// SyntaxProto3 is a Syntax of type proto3.
+	SyntaxProto3
+	// SyntaxEditions is a Syntax of type editions.
+	SyntaxEditions
+)
+const (
+	// TaskStatusUnspecified is a TaskStatus of type unspecified.
+	TaskStatusUnspecified TaskStatus = iota
+	// TaskStatusBacklog is a TaskStatus of type backlog.
+	TaskStatusBacklog
+	// TaskStatusDoing is a TaskStatus of type doing.
+	TaskStatusDoing
+	// TaskStatusDone is a TaskStatus of type done.
+	TaskStatusDone
+)
+
+func init() {
+	structure.RegisterMapper[Cardinality, descriptorpb.FieldDescriptorProto_Label](func(from reflect.Value, to reflect.Value) error {
+		v, err := from.Interface().(Cardinality).Proto()
+		if err == nil {
+			to.Set(reflect.ValueOf(v))
+		}
+		return err
+	})
+	structure.RegisterMapper[descriptorpb.FieldDescriptorProto_Label, Cardinality](func(from reflect.Value, to reflect.Value) error {
+		v, err := CardinalityFromProto(from.Interface().(descriptorpb.FieldDescriptorProto_Label))
+		if err == nil {
+			to.Set(reflect.ValueOf(v))
+		}
+		return err
+	})
+}
+
+func init() {
+	structure.RegisterMapper[Syntax, typepb.Syntax](func(from reflect.Value, to reflect.Value) error {
+		v, err := from.Interface().(Syntax).Proto()
+		if err == nil {
+			to.Set(reflect.ValueOf(v))
+		}
+		return err
+	})
+	structure.RegisterMapper[typepb.Syntax, Syntax](func(from reflect.Value, to reflect.Value) error {
+		v, err := SyntaxFromProto(from.Interface().(typepb.Syntax))
+		if err == nil {
+			to.Set(reflect.ValueOf(v))
+		}
+		return err
+	})
+}
+
+func init() {
+	structure.RegisterMapper[TaskStatus, pb.TaskState](func(from reflect.Value, to reflect.Value) error {
+		v, err := from.Interface().(TaskStatus).Proto()
+		if err == nil {
+			to.Set(reflect.ValueOf(v))
+		}
+		return err
+	})
+	structure.RegisterMapper[pb.TaskState, TaskStatus](func(from reflect.Value, to reflect.Value) error {
+		v, err := TaskStatusFromProto(from.Interface().(pb.TaskState))
+		if err == nil {
+			to.Set(reflect.ValueOf(v))
+		}
+		return err
+	})
+}
+
+var ErrInvalidCardinality = errors.New("not a valid Cardinality")
+
+var _CardinalityNameMap = map[string]Cardinality{
+	"single":   CardinalitySingle,
+	"many":     CardinalityMany,
+	"required": CardinalityRequired,
+	"unknown":  CardinalityUnknown,
+}
+
+// Name is the attribute of Cardinality.
+func (x Cardinality) Name() string {
+	if v, ok := _CardinalityNameMap[string(x)]; ok {
+		return string(v)
+	}
+	return fmt.Sprintf("Cardinality(%s).Name", string(x))
+}
+
+var _CardinalityMapLabel = map[Cardinality]string{
+	CardinalitySingle:   "LABEL_OPTIONAL",
+	CardinalityMany:     "LABEL_REPEATED",
+	CardinalityRequired: "LABEL_REQUIRED",
+	CardinalityUnknown:  "_",
+}
+
+// Label is the attribute of Cardinality.
+func (x Cardinality) Label() string {
+	if v, ok := _CardinalityMapLabel[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Cardinality(%s).Label", string(x))
+}
+
+// Val is the attribute of Cardinality.
+func (x Cardinality) Val() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Cardinality) IsValid() bool {
+	_, ok := _CardinalityNameMap[string(x)]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Cardinality) String() string {
+	return x.Name()
+}
+
+// ParseCardinality converts a string to a Cardinality.
+func ParseCardinality(value string) (Cardinality, error) {
+	if x, ok := _CardinalityNameMap[value]; ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("%s is %w", value, ErrInvalidCardinality)
+}
+
+var _CardinalityProtoMap = map[Cardinality]descriptorpb.FieldDescriptorProto_Label{
+	CardinalitySingle:   descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL,
+	CardinalityMany:     descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+	CardinalityRequired: descriptorpb.FieldDescriptorProto_LABEL_REQUIRED,
+}
+
+var _CardinalityFromProtoMap = map[descriptorpb.FieldDescriptorProto_Label]Cardinality{
+	descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL: CardinalitySingle,
+	descriptorpb.FieldDescriptorProto_LABEL_REPEATED: CardinalityMany,
+	descriptorpb.FieldDescriptorProto_LABEL_REQUIRED: CardinalityRequired,
+}
+
+// Proto converts x to the protobuf enum descriptorpb.FieldDescriptorProto_Label.
+func (x Cardinality) Proto() (descriptorpb.FieldDescriptorProto_Label, error) {
+	if v, ok := _CardinalityProtoMap[x]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("%v has no protobuf value, it is %w", x, ErrInvalidCardinality)
+}
+
+// CardinalityFromProto converts the protobuf enum descriptorpb.FieldDescriptorProto_Label to a Cardinality.
+func CardinalityFromProto(v descriptorpb.FieldDescriptorProto_Label) (Cardinality, error) {
+	if x, ok := _CardinalityFromProtoMap[v]; ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("protobuf %v is %w", v, ErrInvalidCardinality)
+}
+
+var ErrInvalidSyntax = errors.New("not a valid Syntax")
+
+var _SyntaxName = "proto2proto3editions"
+
+var _SyntaxMapName = map[Syntax]string{
+	SyntaxProto2:   _SyntaxName[0:6],
+	SyntaxProto3:   _SyntaxName[6:12],
+	SyntaxEditions: _SyntaxName[12:20],
+}
+
+// Name is the attribute of Syntax.
+func (x Syntax) Name() string {
+	if v, ok := _SyntaxMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Syntax(%d).Name", x)
+}
+
+// Val is the attribute of Syntax.
+func (x Syntax) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Syntax) IsValid() bool {
+	_, ok := _SyntaxMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Syntax) String() string {
+	return x.Name()
+}
+
+var _SyntaxNameMap = map[string]Syntax{
+	_SyntaxName[0:6]:   SyntaxProto2,
+	_SyntaxName[6:12]:  SyntaxProto3,
+	_SyntaxName[12:20]: SyntaxEditions,
+}
+
+// ParseSyntax converts a string to a Syntax.
+func ParseSyntax(value string) (Syntax, error) {
+	if x, ok := _SyntaxNameMap[value]; ok {
+		return x, nil
+	}
+	return Syntax(0), fmt.Errorf("%s is %w", value, ErrInvalidSyntax)
+}
+
+var _SyntaxProtoMap = map[Syntax]typepb.Syntax{
+	SyntaxProto2:   typepb.Syntax_SYNTAX_PROTO2,
+	SyntaxProto3:   typepb.Syntax_SYNTAX_PROTO3,
+	SyntaxEditions: typepb.Syntax_SYNTAX_EDITIONS,
+}
+
+var _SyntaxFromProtoMap = map[typepb.Syntax]Syntax{
+	typepb.Syntax_SYNTAX_PROTO2:   SyntaxProto2,
+	typepb.Syntax_SYNTAX_PROTO3:   SyntaxProto3,
+	typepb.Syntax_SYNTAX_EDITIONS: SyntaxEditions,
+}
+
+// Proto converts x to the protobuf enum typepb.Syntax.
+func (x Syntax) Proto() (typepb.Syntax, error) {
+	if v, ok := _SyntaxProtoMap[x]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("%v has no protobuf value, it is %w", x, ErrInvalidSyntax)
+}
+
+// SyntaxFromProto converts the protobuf enum typepb.Syntax to a Syntax.
+func SyntaxFromProto(v typepb.Syntax) (Syntax, error) {
+	if x, ok := _SyntaxFromProtoMap[v]; ok {
+		return x, nil
+	}
+	return Syntax(0), fmt.Errorf("protobuf %v is %w", v, ErrInvalidSyntax)
+}
+
+var ErrInvalidTaskStatus = errors.New("not a valid TaskStatus")
+
+var _TaskStatusName = "unspecifiedbacklogdoingdone"
+
+var _TaskStatusMapName = map[TaskStatus]string{
+	TaskStatusUnspecified: _TaskStatusName[0:11],
+	TaskStatusBacklog:     _TaskStatusName[11:18],
+	TaskStatusDoing:       _TaskStatusName[18:23],
+	TaskStatusDone:        _TaskStatusName[23:27],
+}
+
+// Name is the attribute of TaskStatus.
+func (x TaskStatus) Name() string {
+	if v, ok := _TaskStatusMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("TaskStatus(%d).Name", x)
+}
+
+// Val is the attribute of TaskStatus.
+func (x TaskStatus) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x TaskStatus) IsValid() bool {
+	_, ok := _TaskStatusMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x TaskStatus) String() string {
+	return x.Name()
+}
+
+var _TaskStatusNameMap = map[string]TaskStatus{
+	_TaskStatusName[0:11]:  TaskStatusUnspecified,
+	_TaskStatusName[11:18]: TaskStatusBacklog,
+	_TaskStatusName[18:23]: TaskStatusDoing,
+	_TaskStatusName[23:27]: TaskStatusDone,
+}
+
+// ParseTaskStatus converts a string to a TaskStatus.
+func ParseTaskStatus(value string) (TaskStatus, error) {
+	if x, ok := _TaskStatusNameMap[value]; ok {
+		return x, nil
+	}
+	return TaskStatus(0), fmt.Errorf("%s is %w", value, ErrInvalidTaskStatus)
+}
+
+var _TaskStatusProtoMap = map[TaskStatus]pb.TaskState{
+	TaskStatusUnspecified: pb.TaskState_TASK_STATE_UNSPECIFIED,
+	TaskStatusBacklog:     pb.TaskState_TASK_STATE_TODO,
+	TaskStatusDoing:       pb.TaskState_TASK_STATE_IN_PROGRESS,
+	TaskStatusDone:        pb.TaskState_TASK_STATE_DONE,
+}
+
+var _TaskStatusFromProtoMap = map[pb.TaskState]TaskStatus{
+	pb.TaskState_TASK_STATE_UNSPECIFIED: TaskStatusUnspecified,
+	pb.TaskState_TASK_STATE_TODO:        TaskStatusBacklog,
+	pb.TaskState_TASK_STATE_IN_PROGRESS: TaskStatusDoing,
+	pb.TaskState_TASK_STATE_DONE:        TaskStatusDone,
+}
+
+// Proto converts x to the protobuf enum pb.TaskState.
+func (x TaskStatus) Proto() (pb.TaskState, error) {
+	if v, ok := _TaskStatusProtoMap[x]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("%v has no protobuf value, it is %w", x, ErrInvalidTaskStatus)
+}
+
+// TaskStatusFromProto converts the protobuf enum pb.TaskState to a TaskStatus.
+func TaskStatusFromProto(v pb.TaskState) (TaskStatus, error) {
+	if x, ok := _TaskStatusFromProtoMap[v]; ok {
+		return x, nil
+	}
+	return TaskStatus(0), fmt.Errorf("protobuf %v is %w", v, ErrInvalidTaskStatus)
+}
@@ -0,0 +1,68 @@
+package enum
+
+import (
+	"testing"
+
+	"github.com/peace0phmind/bud/bud/example/pb"
+	"github.com/peace0phmind/bud/structure"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+func TestSyntaxProto(t *testing.T) {
+	v, err := SyntaxProto3.Proto()
+	assert.NoError(t, err)
+	assert.Equal(t, typepb.Syntax_SYNTAX_PROTO3, v)
+
+	x, err := SyntaxFromProto(typepb.Syntax_SYNTAX_EDITIONS)
+	assert.NoError(t, err)
+	assert.Equal(t, SyntaxEditions, x)
+
+	_, err = SyntaxFromProto(typepb.Syntax(9))
+	assert.ErrorIs(t, err, ErrInvalidSyntax)
+	_, err = Syntax(9).Proto()
+	assert.ErrorIs(t, err, ErrInvalidSyntax)
+}
+
+func TestCardinalityProto(t *testing.T) {
+	v, err := CardinalityMany.Proto()
+	assert.NoError(t, err)
+	assert.Equal(t, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, v)
+
+	// the item leaving the Label blank has no protobuf value
+	_, err = CardinalityUnknown.Proto()
+	assert.EqualError(t, err, "unknown has no protobuf value, it is not a valid Cardinality")
+
+	x, err := CardinalityFromProto(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL)
+	assert.NoError(t, err)
+	assert.Equal(t, CardinalitySingle, x)
+}
+
+func TestTaskStatusProto(t *testing.T) {
+	// backlog and doing are matched by the enumgoname options of their protobuf values
+	v, err := TaskStatusBacklog.Proto()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.TaskState_TASK_STATE_TODO, v)
+
+	x, err := TaskStatusFromProto(pb.TaskState_TASK_STATE_IN_PROGRESS)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStatusDoing, x)
+
+	x, err = structure.ConvertTo[TaskStatus](pb.TaskState_TASK_STATE_DONE)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStatusDone, x)
+}
+
+func TestProtoMappers(t *testing.T) {
+	v, err := structure.ConvertTo[typepb.Syntax](SyntaxProto2)
+	assert.NoError(t, err)
+	assert.Equal(t, typepb.Syntax_SYNTAX_PROTO2, v)
+
+	x, err := structure.ConvertTo[Cardinality](descriptorpb.FieldDescriptorProto_LABEL_REQUIRED)
+	assert.NoError(t, err)
+	assert.Equal(t, CardinalityRequired, x)
+
+	_, err = structure.ConvertTo[descriptorpb.FieldDescriptorProto_Label](CardinalityUnknown)
+	assert.ErrorIs(t, err, ErrInvalidCardinality)
+}
@@ -0,0 +1,11 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// @EnumConfig(template="audit.tmpl")
+// @ENUM{
+// create,
+// update,
+// delete,
+// }
+type AuditAction int
@@ -0,0 +1,71 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// AuditActionCreate is an AuditAction of type create.
+	AuditActionCreate AuditAction = iota
+	// AuditActionUpdate is an AuditAction of type update.
+	AuditActionUpdate
+	// AuditActionDelete is an AuditAction of type delete.
+	AuditActionDelete
+)
+
+var ErrInvalidAuditAction = errors.New("not a valid AuditAction")
+
+var _AuditActionName = "createupdatedelete"
+
+var _AuditActionMapName = map[AuditAction]string{
+	AuditActionCreate: _AuditActionName[0:6],
+	AuditActionUpdate: _AuditActionName[6:12],
+	AuditActionDelete: _AuditActionName[12:18],
+}
+
+// Name is the attribute of AuditAction.
+func (x AuditAction) Name() string {
+	if v, ok := _AuditActionMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("AuditAction(%d).Name", x)
+}
+
+// Val is the attribute of AuditAction.
+func (x AuditAction) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x AuditAction) IsValid() bool {
+	_, ok := _AuditActionMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x AuditAction) String() string {
+	return x.Name()
+}
+
+var _AuditActionNameMap = map[string]AuditAction{
+	_AuditActionName[0:6]:   AuditActionCreate,
+	_AuditActionName[6:12]:  AuditActionUpdate,
+	_AuditActionName[12:18]: AuditActionDelete,
+}
+
+// ParseAuditAction converts a string to an AuditAction.
+func ParseAuditAction(value string) (AuditAction, error) {
+	if x, ok := _AuditActionNameMap[value]; ok {
+		return x, nil
+	}
+	return AuditAction(0), fmt.Errorf("%s is %w", value, ErrInvalidAuditAction)
+}
+
+// AuditLabel returns the label of AuditAction written to the audit log.
+func (x AuditAction) AuditLabel() string {
+	return "AuditAction." + x.String()
+}
@@ -0,0 +1,12 @@
+package enum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditActionAuditLabel(t *testing.T) {
+	assert.Equal(t, "AuditAction.create", AuditActionCreate.AuditLabel())
+	assert.Equal(t, "AuditAction.delete", AuditActionDelete.AuditLabel())
+}
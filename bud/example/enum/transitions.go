@@ -0,0 +1,12 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// TicketStatus is the state of a support ticket, it is reopened after a rejection.
+// @ENUM{
+// open     -> inWork, rejected
+// inWork   -> resolved, rejected
+// resolved
+// rejected -> open
+// }
+type TicketStatus int
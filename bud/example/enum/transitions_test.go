@@ -0,0 +1,29 @@
+package enum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketStatusTransitions(t *testing.T) {
+	assert.True(t, TicketStatusOpen.CanTransitionTo(TicketStatusInWork))
+	assert.False(t, TicketStatusOpen.CanTransitionTo(TicketStatusResolved))
+	assert.Equal(t, []TicketStatus{TicketStatusResolved, TicketStatusRejected}, TicketStatusInWork.AllowedNext())
+	assert.Empty(t, TicketStatusResolved.AllowedNext())
+
+	status := TicketStatusOpen
+	assert.NoError(t, status.TransitionTo(TicketStatusRejected))
+	assert.Equal(t, TicketStatusRejected, status)
+
+	err := status.TransitionTo(TicketStatusResolved)
+	assert.EqualError(t, err, "rejected to resolved is not a valid TicketStatus transition")
+	assert.ErrorIs(t, err, ErrInvalidTicketStatusTransition)
+	var transitionErr *TicketStatusTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, TicketStatusTransitionError{From: TicketStatusRejected, To: TicketStatusResolved}, *transitionErr)
+	assert.Equal(t, TicketStatusRejected, status)
+
+	assert.Contains(t, TicketStatusTransitionsDOT(), "\t\"inWork\" -> \"resolved\";\n")
+	assert.Contains(t, TicketStatusTransitionsMermaid(), "    rejected --> open\n")
+}
@@ -0,0 +1,14 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// Timeout has attributes of named types, declared in the package or imported.
+// @ENUM(Duration time.Duration, Fallback Color, Label Label){
+// short(time.Second, ColorRed, fast)
+// medium(30, ColorGreen, normal)
+// long(time.Minute, ColorBlue, slow)
+// }
+type Timeout int
+
+// Label is the display label of a Timeout.
+type Label string
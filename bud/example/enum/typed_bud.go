@@ -0,0 +1,109 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// TimeoutShort is a Timeout of type short.
+	TimeoutShort Timeout = iota
+	// TimeoutMedium is a Timeout of type medium.
+	TimeoutMedium
+	// TimeoutLong is a Timeout of type long.
+	TimeoutLong
+)
+
+var ErrInvalidTimeout = errors.New("not a valid Timeout")
+
+var _TimeoutName = "shortmediumlong"
+
+var _TimeoutMapName = map[Timeout]string{
+	TimeoutShort:  _TimeoutName[0:5],
+	TimeoutMedium: _TimeoutName[5:11],
+	TimeoutLong:   _TimeoutName[11:15],
+}
+
+// Name is the attribute of Timeout.
+func (x Timeout) Name() string {
+	if v, ok := _TimeoutMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Timeout(%d).Name", x)
+}
+
+var _TimeoutMapDuration = map[Timeout]time.Duration{
+	TimeoutShort:  time.Second,
+	TimeoutMedium: 30,
+	TimeoutLong:   time.Minute,
+}
+
+// Duration is the attribute of Timeout.
+func (x Timeout) Duration() time.Duration {
+	if v, ok := _TimeoutMapDuration[x]; ok {
+		return v
+	}
+	return 0
+}
+
+var _TimeoutMapFallback = map[Timeout]Color{
+	TimeoutShort:  ColorRed,
+	TimeoutMedium: ColorGreen,
+	TimeoutLong:   ColorBlue,
+}
+
+// Fallback is the attribute of Timeout.
+func (x Timeout) Fallback() Color {
+	if v, ok := _TimeoutMapFallback[x]; ok {
+		return v
+	}
+	return 0
+}
+
+var _TimeoutMapLabel = map[Timeout]Label{
+	TimeoutShort:  "fast",
+	TimeoutMedium: "normal",
+	TimeoutLong:   "slow",
+}
+
+// Label is the attribute of Timeout.
+func (x Timeout) Label() Label {
+	if v, ok := _TimeoutMapLabel[x]; ok {
+		return v
+	}
+	return ""
+}
+
+// Val is the attribute of Timeout.
+func (x Timeout) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Timeout) IsValid() bool {
+	_, ok := _TimeoutMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Timeout) String() string {
+	return x.Name()
+}
+
+var _TimeoutNameMap = map[string]Timeout{
+	_TimeoutName[0:5]:   TimeoutShort,
+	_TimeoutName[5:11]:  TimeoutMedium,
+	_TimeoutName[11:15]: TimeoutLong,
+}
+
+// ParseTimeout converts a string to a Timeout.
+func ParseTimeout(value string) (Timeout, error) {
+	if x, ok := _TimeoutNameMap[value]; ok {
+		return x, nil
+	}
+	return Timeout(0), fmt.Errorf("%s is %w", value, ErrInvalidTimeout)
+}
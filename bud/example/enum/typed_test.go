@@ -0,0 +1,18 @@
+package enum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutNamedAttributes(t *testing.T) {
+	assert.Equal(t, time.Second, TimeoutShort.Duration())
+	assert.Equal(t, time.Duration(30), TimeoutMedium.Duration())
+	assert.Equal(t, time.Minute, TimeoutLong.Duration())
+	assert.Equal(t, ColorBlue, TimeoutLong.Fallback())
+	assert.Equal(t, Label("normal"), TimeoutMedium.Label())
+	assert.Equal(t, time.Duration(0), Timeout(10).Duration())
+	assert.Equal(t, Label(""), Timeout(10).Label())
+}
@@ -0,0 +1,21 @@
+package enum
+
+//go:generate go run ../../../main.go
+
+// Fruit is sold in the shop, the frontend shows its color and calories.
+// @EnumConfig(marshal, forceUpper, noCase, typescript)
+// @ENUM(Name string, Color string, Calories int){
+// apple(_, red, 52)
+// banana(_, yellow, 89)
+// cherry(_, _, 50)
+// }
+type Fruit int
+
+// Unit is stored as its value.
+// @EnumConfig(typescript)
+// @ENUM{gram = 1, kilogram = 1000}
+type Unit int
+
+// @EnumConfig(typescript)
+// @ENUM{usd, eur}
+type Currency string
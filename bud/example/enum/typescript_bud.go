@@ -0,0 +1,215 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// CurrencyUsd is a Currency of type usd.
+	CurrencyUsd Currency = "usd"
+	// CurrencyEur is a Currency of type eur.
+	CurrencyEur Currency = "eur"
+)
+const (
+	// FruitApple is a Fruit of type APPLE.
+	FruitApple Fruit = iota
+	// FruitBanana is a Fruit of type BANANA.
+	FruitBanana
+	// FruitCherry is a Fruit of type CHERRY.
+	FruitCherry
+)
+const (
+	// UnitGram is an Unit of type gram.
+	UnitGram Unit = 1
+	// UnitKilogram is an Unit of type kilogram.
+	UnitKilogram Unit = 1000
+)
+
+var ErrInvalidCurrency = errors.New("not a valid Currency")
+
+var _CurrencyNameMap = map[string]Currency{
+	"usd": CurrencyUsd,
+	"eur": CurrencyEur,
+}
+
+// Name is the attribute of Currency.
+func (x Currency) Name() string {
+	if v, ok := _CurrencyNameMap[string(x)]; ok {
+		return string(v)
+	}
+	return fmt.Sprintf("Currency(%s).Name", string(x))
+}
+
+// Val is the attribute of Currency.
+func (x Currency) Val() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Currency) IsValid() bool {
+	_, ok := _CurrencyNameMap[string(x)]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Currency) String() string {
+	return x.Name()
+}
+
+// ParseCurrency converts a string to a Currency.
+func ParseCurrency(value string) (Currency, error) {
+	if x, ok := _CurrencyNameMap[value]; ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("%s is %w", value, ErrInvalidCurrency)
+}
+
+var ErrInvalidFruit = errors.New("not a valid Fruit")
+
+var _FruitName = "APPLEBANANACHERRY"
+
+var _FruitMapName = map[Fruit]string{
+	FruitApple:  _FruitName[0:5],
+	FruitBanana: _FruitName[5:11],
+	FruitCherry: _FruitName[11:17],
+}
+
+// Name is the attribute of Fruit.
+func (x Fruit) Name() string {
+	if v, ok := _FruitMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Fruit(%d).Name", x)
+}
+
+var _FruitMapColor = map[Fruit]string{
+	FruitApple:  "red",
+	FruitBanana: "yellow",
+	FruitCherry: "_",
+}
+
+// Color is the attribute of Fruit.
+func (x Fruit) Color() string {
+	if v, ok := _FruitMapColor[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Fruit(%d).Color", x)
+}
+
+var _FruitMapCalories = map[Fruit]int{
+	FruitApple:  52,
+	FruitBanana: 89,
+	FruitCherry: 50,
+}
+
+// Calories is the attribute of Fruit.
+func (x Fruit) Calories() int {
+	if v, ok := _FruitMapCalories[x]; ok {
+		return v
+	}
+	return 0
+}
+
+// Val is the attribute of Fruit.
+func (x Fruit) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Fruit) IsValid() bool {
+	_, ok := _FruitMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Fruit) String() string {
+	return x.Name()
+}
+
+var _FruitNameMap = map[string]Fruit{
+	_FruitName[0:5]:                    FruitApple,
+	strings.ToLower(_FruitName[0:5]):   FruitApple,
+	_FruitName[5:11]:                   FruitBanana,
+	strings.ToLower(_FruitName[5:11]):  FruitBanana,
+	_FruitName[11:17]:                  FruitCherry,
+	strings.ToLower(_FruitName[11:17]): FruitCherry,
+}
+
+// ParseFruit converts a string to a Fruit.
+func ParseFruit(value string) (Fruit, error) {
+	if x, ok := _FruitNameMap[value]; ok {
+		return x, nil
+	}
+	if x, ok := _FruitNameMap[strings.ToLower(value)]; ok {
+		return x, nil
+	}
+	return Fruit(0), fmt.Errorf("%s is %w", value, ErrInvalidFruit)
+}
+
+// MarshalText implements the text marshaller method.
+func (x Fruit) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *Fruit) UnmarshalText(text []byte) error {
+	val, err := ParseFruit(string(text))
+	if err != nil {
+		return err
+	}
+	*x = val
+	return nil
+}
+
+var ErrInvalidUnit = errors.New("not a valid Unit")
+
+var _UnitName = "gramkilogram"
+
+var _UnitMapName = map[Unit]string{
+	UnitGram:     _UnitName[0:4],
+	UnitKilogram: _UnitName[4:12],
+}
+
+// Name is the attribute of Unit.
+func (x Unit) Name() string {
+	if v, ok := _UnitMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Unit(%d).Name", x)
+}
+
+// Val is the attribute of Unit.
+func (x Unit) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Unit) IsValid() bool {
+	_, ok := _UnitMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Unit) String() string {
+	return x.Name()
+}
+
+var _UnitNameMap = map[string]Unit{
+	_UnitName[0:4]:  UnitGram,
+	_UnitName[4:12]: UnitKilogram,
+}
+
+// ParseUnit converts a string to an Unit.
+func ParseUnit(value string) (Unit, error) {
+	if x, ok := _UnitNameMap[value]; ok {
+		return x, nil
+	}
+	return Unit(0), fmt.Errorf("%s is %w", value, ErrInvalidUnit)
+}
@@ -0,0 +1,45 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+export type Currency = "usd" | "eur";
+
+export const Currency = {
+  Usd: { Name: "usd", Val: "usd" },
+  Eur: { Name: "eur", Val: "eur" },
+} as const;
+
+export const CurrencyValues: readonly Currency[] = ["usd", "eur"];
+
+// Fruit is sold in the shop, the frontend shows its color and calories.
+export type Fruit = "APPLE" | "BANANA" | "CHERRY";
+
+export const Fruit = {
+  Apple: { Name: "APPLE", Color: "red", Calories: 52, Val: 0 },
+  Banana: { Name: "BANANA", Color: "yellow", Calories: 89, Val: 1 },
+  Cherry: { Name: "CHERRY", Calories: 50, Val: 2 },
+} as const;
+
+export const FruitValues: readonly Fruit[] = ["APPLE", "BANANA", "CHERRY"];
+
+const _FruitParse = new Map<string, Fruit>([
+  ["APPLE", "APPLE"],
+  ["apple", "APPLE"],
+  ["BANANA", "BANANA"],
+  ["banana", "BANANA"],
+  ["CHERRY", "CHERRY"],
+  ["cherry", "CHERRY"],
+]);
+
+// parseFruit returns the Fruit of text, or undefined if text is not a Fruit.
+export function parseFruit(text: string): Fruit | undefined {
+  return _FruitParse.get(text) ?? _FruitParse.get(text.toLowerCase());
+}
+
+// Unit is stored as its value.
+export type Unit = 1 | 1000;
+
+export const Unit = {
+  Gram: { Name: "gram", Val: 1 },
+  Kilogram: { Name: "kilogram", Val: 1000 },
+} as const;
+
+export const UnitValues: readonly Unit[] = [1, 1000];
@@ -0,0 +1,33 @@
+package enum
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeScriptValues(t *testing.T) {
+	content, err := os.ReadFile("typescript_bud.ts")
+	assert.NoError(t, err)
+	ts := string(content)
+
+	// the values in TypeScript are the texts the go side marshals to and parses
+	var values []string
+	for _, fruit := range []Fruit{FruitApple, FruitBanana, FruitCherry} {
+		text, err := fruit.MarshalText()
+		assert.NoError(t, err)
+		values = append(values, fmt.Sprintf("%q", text))
+
+		parsed, err := ParseFruit(strings.ToLower(string(text)))
+		assert.NoError(t, err)
+		assert.Equal(t, fruit, parsed)
+	}
+	assert.Contains(t, ts, "export type Fruit = "+strings.Join(values, " | ")+";\n")
+	assert.Contains(t, ts, `  Cherry: { Name: "CHERRY", Calories: 50, Val: 2 },`)
+
+	assert.Contains(t, ts, fmt.Sprintf("export const UnitValues: readonly Unit[] = [%d, %d];\n", UnitGram, UnitKilogram))
+	assert.Contains(t, ts, fmt.Sprintf("export type Currency = %q | %q;\n", CurrencyUsd, CurrencyEur))
+}
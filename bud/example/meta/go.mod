@@ -0,0 +1,3 @@
+module github.com/peace0phmind/bud/bud/example/meta
+
+go 1.20
@@ -0,0 +1,20 @@
+package meta
+
+// @DbEnum
+// @ENUM{pending, inWork, completed, rejected}
+type ProjectStatus int
+
+// @DbEnum(sqlName=dbCode)
+//
+//	@ENUM(dbCode int) {
+//		pending(0)
+//		inWork(10)
+//		completed(20)
+//		rejected(30)
+//	}
+type ProjectStatusCode string
+
+// @DbEnum
+// @EnumConfig(marshal=false)
+// @ENUM{low, high}
+type Priority int
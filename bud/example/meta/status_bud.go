@@ -0,0 +1,428 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package meta
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
+const (
+	ProjectStatusPending ProjectStatus = iota
+	ProjectStatusInWork
+	ProjectStatusCompleted
+	ProjectStatusRejected
+)
+const (
+	ProjectStatusCodePending   ProjectStatusCode = "pending"
+	ProjectStatusCodeInWork    ProjectStatusCode = "inWork"
+	ProjectStatusCodeCompleted ProjectStatusCode = "completed"
+	ProjectStatusCodeRejected  ProjectStatusCode = "rejected"
+)
+
+var ErrInvalidPriority = errors.New("not a valid Priority")
+
+var _PriorityName = "lowhigh"
+
+var _PriorityMapName = map[Priority]string{
+	PriorityLow:  _PriorityName[0:3],
+	PriorityHigh: _PriorityName[3:7],
+}
+
+// Name is the attribute of Priority.
+func (x Priority) Name() string {
+	if v, ok := _PriorityMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Priority(%d).Name", x)
+}
+
+// Val is the attribute of Priority.
+func (x Priority) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Priority) IsValid() bool {
+	_, ok := _PriorityMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Priority) String() string {
+	return x.Name()
+}
+
+var _PriorityNameMap = map[string]Priority{
+	_PriorityName[0:3]: PriorityLow,
+	_PriorityName[3:7]: PriorityHigh,
+}
+
+// ParsePriority converts a string to a Priority.
+func ParsePriority(value string) (Priority, error) {
+	if x, ok := _PriorityNameMap[value]; ok {
+		return x, nil
+	}
+	return Priority(0), fmt.Errorf("%s is %w", value, ErrInvalidPriority)
+}
+
+func (x Priority) Ptr() *Priority {
+	return &x
+}
+
+var ErrPriorityNilPtr = errors.New("value pointer is nil")
+
+// Scan implements the Scanner interface.
+func (x *Priority) Scan(value any) (err error) {
+	if value == nil {
+		*x = Priority(0)
+		return
+	}
+
+	switch v := value.(type) {
+	case int:
+		*x = Priority(v)
+	case int64:
+		*x = Priority(v)
+	case uint:
+		*x = Priority(v)
+	case uint64:
+		*x = Priority(v)
+	case float64:
+		*x = Priority(v)
+	case *int:
+		if v == nil {
+			return ErrPriorityNilPtr
+		}
+		*x = Priority(*v)
+	case *int64:
+		if v == nil {
+			return ErrPriorityNilPtr
+		}
+		*x = Priority(*v)
+	case *uint:
+		if v == nil {
+			return ErrPriorityNilPtr
+		}
+		*x = Priority(*v)
+	case *uint64:
+		if v == nil {
+			return ErrPriorityNilPtr
+		}
+		*x = Priority(*v)
+	case *float64:
+		if v == nil {
+			return ErrPriorityNilPtr
+		}
+		*x = Priority(*v)
+	case Priority:
+		*x = v
+	case *Priority:
+		if v == nil {
+			return ErrPriorityNilPtr
+		}
+		*x = *v
+	}
+
+	if !x.IsValid() {
+		return ErrInvalidPriority
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x Priority) Value() (driver.Value, error) {
+	return x.Val(), nil
+}
+
+var ErrInvalidProjectStatus = errors.New("not a valid ProjectStatus")
+
+var _ProjectStatusName = "pendinginWorkcompletedrejected"
+
+var _ProjectStatusMapName = map[ProjectStatus]string{
+	ProjectStatusPending:   _ProjectStatusName[0:7],
+	ProjectStatusInWork:    _ProjectStatusName[7:13],
+	ProjectStatusCompleted: _ProjectStatusName[13:22],
+	ProjectStatusRejected:  _ProjectStatusName[22:30],
+}
+
+// Name is the attribute of ProjectStatus.
+func (x ProjectStatus) Name() string {
+	if v, ok := _ProjectStatusMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("ProjectStatus(%d).Name", x)
+}
+
+// Val is the attribute of ProjectStatus.
+func (x ProjectStatus) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ProjectStatus) IsValid() bool {
+	_, ok := _ProjectStatusMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x ProjectStatus) String() string {
+	return x.Name()
+}
+
+var _ProjectStatusNameMap = map[string]ProjectStatus{
+	_ProjectStatusName[0:7]:   ProjectStatusPending,
+	_ProjectStatusName[7:13]:  ProjectStatusInWork,
+	_ProjectStatusName[13:22]: ProjectStatusCompleted,
+	_ProjectStatusName[22:30]: ProjectStatusRejected,
+}
+
+// ParseProjectStatus converts a string to a ProjectStatus.
+func ParseProjectStatus(value string) (ProjectStatus, error) {
+	if x, ok := _ProjectStatusNameMap[value]; ok {
+		return x, nil
+	}
+	return ProjectStatus(0), fmt.Errorf("%s is %w", value, ErrInvalidProjectStatus)
+}
+
+func (x ProjectStatus) Ptr() *ProjectStatus {
+	return &x
+}
+
+// MarshalText implements the text marshaller method.
+func (x ProjectStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *ProjectStatus) UnmarshalText(text []byte) error {
+	val, err := ParseProjectStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*x = val
+	return nil
+}
+
+var ErrProjectStatusNilPtr = errors.New("value pointer is nil")
+
+// Scan implements the Scanner interface.
+func (x *ProjectStatus) Scan(value any) (err error) {
+	if value == nil {
+		*x = ProjectStatus(0)
+		return
+	}
+
+	switch v := value.(type) {
+	case int:
+		*x = ProjectStatus(v)
+	case int64:
+		*x = ProjectStatus(v)
+	case uint:
+		*x = ProjectStatus(v)
+	case uint64:
+		*x = ProjectStatus(v)
+	case float64:
+		*x = ProjectStatus(v)
+	case *int:
+		if v == nil {
+			return ErrProjectStatusNilPtr
+		}
+		*x = ProjectStatus(*v)
+	case *int64:
+		if v == nil {
+			return ErrProjectStatusNilPtr
+		}
+		*x = ProjectStatus(*v)
+	case *uint:
+		if v == nil {
+			return ErrProjectStatusNilPtr
+		}
+		*x = ProjectStatus(*v)
+	case *uint64:
+		if v == nil {
+			return ErrProjectStatusNilPtr
+		}
+		*x = ProjectStatus(*v)
+	case *float64:
+		if v == nil {
+			return ErrProjectStatusNilPtr
+		}
+		*x = ProjectStatus(*v)
+	case ProjectStatus:
+		*x = v
+	case *ProjectStatus:
+		if v == nil {
+			return ErrProjectStatusNilPtr
+		}
+		*x = *v
+	}
+
+	if !x.IsValid() {
+		return ErrInvalidProjectStatus
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x ProjectStatus) Value() (driver.Value, error) {
+	return x.Val(), nil
+}
+
+var ErrInvalidProjectStatusCode = errors.New("not a valid ProjectStatusCode")
+
+var _ProjectStatusCodeNameMap = map[string]ProjectStatusCode{
+	"pending":   ProjectStatusCodePending,
+	"inWork":    ProjectStatusCodeInWork,
+	"completed": ProjectStatusCodeCompleted,
+	"rejected":  ProjectStatusCodeRejected,
+}
+
+// Name is the attribute of ProjectStatusCode.
+func (x ProjectStatusCode) Name() string {
+	if v, ok := _ProjectStatusCodeNameMap[string(x)]; ok {
+		return string(v)
+	}
+	return fmt.Sprintf("ProjectStatusCode(%s).Name", string(x))
+}
+
+var _ProjectStatusCodeMapDbCode = map[ProjectStatusCode]int{
+	ProjectStatusCodePending:   0,
+	ProjectStatusCodeInWork:    10,
+	ProjectStatusCodeCompleted: 20,
+	ProjectStatusCodeRejected:  30,
+}
+
+// DbCode is the attribute of ProjectStatusCode.
+func (x ProjectStatusCode) DbCode() int {
+	if v, ok := _ProjectStatusCodeMapDbCode[x]; ok {
+		return v
+	}
+	return 0
+}
+
+// Val is the attribute of ProjectStatusCode.
+func (x ProjectStatusCode) Val() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ProjectStatusCode) IsValid() bool {
+	_, ok := _ProjectStatusCodeNameMap[string(x)]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x ProjectStatusCode) String() string {
+	return x.Name()
+}
+
+// ParseProjectStatusCode converts a string to a ProjectStatusCode.
+func ParseProjectStatusCode(value string) (ProjectStatusCode, error) {
+	if x, ok := _ProjectStatusCodeNameMap[value]; ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("%s is %w", value, ErrInvalidProjectStatusCode)
+}
+
+func (x ProjectStatusCode) Ptr() *ProjectStatusCode {
+	return &x
+}
+
+// MarshalText implements the text marshaller method.
+func (x ProjectStatusCode) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *ProjectStatusCode) UnmarshalText(text []byte) error {
+	val, err := ParseProjectStatusCode(string(text))
+	if err != nil {
+		return err
+	}
+	*x = val
+	return nil
+}
+
+var ErrProjectStatusCodeNilPtr = errors.New("value pointer is nil")
+
+var _ProjectStatusCodeDbCodeMap = map[int]ProjectStatusCode{
+	0:  ProjectStatusCodePending,
+	10: ProjectStatusCodeInWork,
+	20: ProjectStatusCodeCompleted,
+	30: ProjectStatusCodeRejected,
+}
+
+// Scan implements the Scanner interface.
+func (x *ProjectStatusCode) Scan(value any) (err error) {
+	if value == nil {
+		*x = ""
+		return
+	}
+
+	var ok bool
+	switch v := value.(type) {
+	case int:
+		*x, ok = _ProjectStatusCodeDbCodeMap[v]
+	case int64:
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(v)]
+	case uint:
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(v)]
+	case uint64:
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(v)]
+	case float64:
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(v)]
+	case *int:
+		if v == nil {
+			return ErrProjectStatusCodeNilPtr
+		}
+		*x, ok = _ProjectStatusCodeDbCodeMap[*v]
+	case *int64:
+		if v == nil {
+			return ErrProjectStatusCodeNilPtr
+		}
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(*v)]
+	case *uint:
+		if v == nil {
+			return ErrProjectStatusCodeNilPtr
+		}
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(*v)]
+	case *uint64:
+		if v == nil {
+			return ErrProjectStatusCodeNilPtr
+		}
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(*v)]
+	case *float64:
+		if v == nil {
+			return ErrProjectStatusCodeNilPtr
+		}
+		*x, ok = _ProjectStatusCodeDbCodeMap[int(*v)]
+	case ProjectStatusCode:
+		*x = v
+		ok = x.IsValid()
+	case *ProjectStatusCode:
+		if v == nil {
+			return ErrProjectStatusCodeNilPtr
+		}
+		*x = *v
+		ok = x.IsValid()
+	}
+
+	if !ok {
+		return ErrInvalidProjectStatusCode
+	}
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x ProjectStatusCode) Value() (driver.Value, error) {
+	return x.DbCode(), nil
+}
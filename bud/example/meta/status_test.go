@@ -0,0 +1,29 @@
+package meta
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestDbEnum(t *testing.T) {
+	if v, err := ProjectStatusInWork.Value(); err != nil || v != 1 {
+		t.Errorf("ProjectStatusInWork.Value() = %v, %v", v, err)
+	}
+
+	if v, err := ProjectStatusCodeCompleted.Value(); err != nil || v != 20 {
+		t.Errorf("ProjectStatusCodeCompleted.Value() = %v, %v", v, err)
+	}
+
+	if b, err := ProjectStatusRejected.MarshalText(); err != nil || string(b) != "rejected" {
+		t.Errorf("ProjectStatusRejected.MarshalText() = %s, %v", b, err)
+	}
+
+	if *ProjectStatusPending.Ptr() != ProjectStatusPending {
+		t.Errorf("ProjectStatusPending.Ptr() is not ProjectStatusPending")
+	}
+
+	// marshal is turned off on the expanded annotation
+	if _, ok := any(PriorityHigh).(encoding.TextMarshaler); ok {
+		t.Errorf("Priority implements encoding.TextMarshaler")
+	}
+}
@@ -0,0 +1,19 @@
+package registry
+
+//go:generate go run ../../../main.go
+
+// UserService serves the users.
+// @Registry
+// @Singleton
+// @Rest(path="/users", methods=[GET, POST], auth=@Role(name=admin, level=2), limits={read: 100, write: 10})
+type UserService struct{}
+
+// OrderService serves the orders, only its Rest annotation is registered.
+// @Registry(only=[Rest])
+// @Singleton
+// @Rest(path="/orders")
+type OrderService struct{}
+
+// Plain has no annotation registered, because it is not marked for the registry.
+// @Singleton
+type Plain struct{}
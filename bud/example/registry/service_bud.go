@@ -0,0 +1,17 @@
+// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.
+
+package registry
+
+import (
+	"github.com/peace0phmind/bud/annotations"
+)
+
+func init() {
+	annotations.Register[UserService](
+		&annotations.Annotation{Name: "Singleton"},
+		&annotations.Annotation{Name: "Rest", Params: []annotations.Param{{Key: "path", Value: "/users"}, {Key: "methods", Value: []any{"GET", "POST"}}, {Key: "auth", Value: &annotations.Annotation{Name: "Role", Params: []annotations.Param{{Key: "name", Value: "admin"}, {Key: "level", Value: 2}}}}, {Key: "limits", Value: map[string]any{"read": 100, "write": 10}}}},
+	)
+	annotations.Register[OrderService](
+		&annotations.Annotation{Name: "Rest", Params: []annotations.Param{{Key: "path", Value: "/orders"}}},
+	)
+}
@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"github.com/peace0phmind/bud/annotations"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestUserServiceAnnotations(t *testing.T) {
+	assert.Len(t, annotations.Of[UserService](), 2)
+	assert.NotNil(t, annotations.Find[UserService]("Singleton"))
+
+	rest := annotations.Find[UserService]("Rest")
+	path, _ := rest.Param("path")
+	assert.Equal(t, "/users", path)
+	methods, _ := rest.Param("methods")
+	assert.Equal(t, []any{"GET", "POST"}, methods)
+	limits, _ := rest.Param("limits")
+	assert.Equal(t, map[string]any{"read": 100, "write": 10}, limits)
+
+	auth, _ := rest.Param("auth")
+	level, _ := auth.(*annotations.Annotation).Param("level")
+	assert.Equal(t, 2, level)
+}
+
+func TestRegistryOnly(t *testing.T) {
+	assert.Nil(t, annotations.Find[OrderService]("Singleton"))
+	assert.NotNil(t, annotations.Find[OrderService]("Rest"))
+	assert.Nil(t, annotations.Of[Plain]())
+
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(OrderService{}), reflect.TypeOf(UserService{})}, annotations.Types("Rest"))
+}
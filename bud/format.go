@@ -0,0 +1,85 @@
+package bud
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/peace0phmind/bud/bud/ast"
+	goast "go/ast"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+)
+
+// FormatPackages rewrites the annotations of the go files of all packages matched by patterns in the canonical layout
+// of ast.FormatCommentGroup. The name of every changed file is written to w, the files are only written if write is set.
+func FormatPackages(patterns []string, write bool, w io.Writer) (diagnostics ast.Diagnostics) {
+	fileSet := token.NewFileSet()
+
+	pkgs, err := LoadPackages(fileSet, patterns...)
+	diagnostics.Append(err, token.Position{})
+
+	for _, pkg := range pkgs {
+		for _, fileNode := range pkg.Files {
+			filename := fileSet.Position(fileNode.Package).Filename
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				diagnostics.Append(err, token.Position{Filename: filename})
+				continue
+			}
+
+			formatted, fd := FormatFile(fileNode, fileSet, src)
+			diagnostics.Add(fd...)
+			if bytes.Equal(src, formatted) {
+				continue
+			}
+
+			fmt.Fprintln(w, filename)
+			if write {
+				diagnostics.Append(writeFile(filename, formatted), token.Position{Filename: filename})
+			}
+		}
+	}
+
+	return
+}
+
+// FormatFile returns src with the annotations of the comment groups of the declarations of the file in the canonical
+// layout of ast.FormatCommentGroup, the other text of the comments is kept as it is. A comment group starting with an
+// annotation which can not be parsed is reported as a warning and left as it is.
+func FormatFile(fileNode *goast.File, fileSet *token.FileSet, src []byte) ([]byte, ast.Diagnostics) {
+	var diagnostics ast.Diagnostics
+	targets := annotationTargets(fileNode, fileSet)
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+
+	for _, cg := range fileNode.Comments {
+		if targets[cg] == nil || !strings.Contains(cg.Text(), "@") {
+			continue
+		}
+
+		text, err := ast.FormatCommentGroup(fileSet, cg, src)
+		if err != nil {
+			diagnostics.Add(ast.Warnings(err, fileSet.Position(cg.Pos()))...)
+			continue
+		}
+
+		start, end := fileSet.Position(cg.Pos()).Offset, fileSet.Position(cg.End()).Offset
+		if text != string(src[start:end]) {
+			edits = append(edits, edit{start: start, end: end, text: text})
+		}
+	}
+
+	// the comment groups are in source order, they are replaced from the end so the offsets stay valid
+	result := src
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		result = append(append(append([]byte{}, result[:e.start]...), e.text...), result[e.end:]...)
+	}
+
+	return result, diagnostics
+}
@@ -0,0 +1,122 @@
+package bud
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatPackages(t *testing.T) {
+	dir := t.TempDir()
+	src := `package color
+
+//@EnumConfig(marshal,ptr)
+//go:generate bud
+
+// Color doc
+/* @ENUM(Name string, Code int){
+red(_,1), green_light("green light",22)
+blue(_,3) // blue color
+}
+*/
+type Color int
+
+type User struct {
+	// @Column( name = id )
+	ID   int
+	Name string // @Column(name=name,size=20)
+	/* @Ignore( ) */ Age int
+}
+
+// @Broken(a=[1)
+type Broken int
+`
+	color := filepath.Join(dir, "color.go")
+	assert.NoError(t, os.WriteFile(color, []byte(src), 0o644))
+
+	w := &bytes.Buffer{}
+	diagnostics := FormatPackages([]string{dir}, false, w)
+	assert.False(t, diagnostics.HasErrors(), diagnostics.Error())
+	assert.Equal(t, color+"\n", w.String())
+	content, err := os.ReadFile(color)
+	assert.NoError(t, err)
+	assert.Equal(t, src, string(content))
+
+	w.Reset()
+	diagnostics = FormatPackages([]string{dir}, true, w)
+	assert.False(t, diagnostics.HasErrors(), diagnostics.Error())
+	assert.Len(t, diagnostics, 1, "the unparsable @Broken is a warning")
+	assert.Equal(t, color+"\n", w.String())
+	content, err = os.ReadFile(color)
+	assert.NoError(t, err)
+	assert.Equal(t, `package color
+
+//@EnumConfig(marshal, ptr)
+//go:generate bud
+
+// Color doc
+/* @ENUM(Name string, Code int) {
+red        (_,             1)
+green_light("green light", 22)
+blue       (_,             3) // blue color
+}
+*/
+type Color int
+
+type User struct {
+	// @Column(name=id)
+	ID   int
+	Name string // @Column(name=name, size=20)
+	/* @Ignore( ) */ Age int
+}
+
+// @Broken(a=[1)
+type Broken int
+`, string(content))
+
+	// the formatted file is left as it is
+	w.Reset()
+	FormatPackages([]string{dir}, true, w)
+	assert.Empty(t, w.String())
+}
+
+func TestFormatFileKeepsComments(t *testing.T) {
+	src := `package doc
+
+// Doc is a plain doc comment, it holds an "@" as well.
+// It is written like @Name or @EnumConfig in the docs, mail user@example.com.
+type Doc int
+
+// Config is read from a file like
+//
+//	metaAnnotations:
+//	  DbEnum: "@EnumConfig(sql, marshal)"
+type Config struct{}
+
+// Registry is the @Registry annotation, given in the comment group of //go:generate.
+type Registry struct{}
+
+// Status is a status.
+//
+//	@ENUM(Code int) {
+//		pending(0)
+//		done(10)
+//	}
+type Status string
+
+// Size @ENUM{small,large}, see the docs
+type Size int
+`
+	fileSet := token.NewFileSet()
+	fileNode, err := parser.ParseFile(fileSet, "doc.go", src, parser.ParseComments)
+	assert.NoError(t, err)
+
+	formatted, diagnostics := FormatFile(fileNode, fileSet, []byte(src))
+	assert.Empty(t, diagnostics)
+	assert.Equal(t, strings.Replace(src, "@ENUM{small,large}", "@ENUM{small, large}", 1), string(formatted))
+}
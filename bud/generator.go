@@ -4,75 +4,290 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/peace0phmind/bud/bud/ast"
-	"github.com/peace0phmind/bud/bud/enum"
+	_ "github.com/peace0phmind/bud/bud/enum"
+	_ "github.com/peace0phmind/bud/bud/registry"
+	_ "github.com/peace0phmind/bud/bud/singleton"
+	goast "go/ast"
+	"go/token"
 	"golang.org/x/tools/imports"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
-func GenerateFile(filename string, outputSuffix string) {
+const GeneratedHeader = "// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT."
+
+// GenerateFile generates the bud file of the source file filename.
+// It returns the errors and warnings found in the source file, the bud file is not written if there is any error.
+func GenerateFile(filename string, outputSuffix string) (diagnostics ast.Diagnostics) {
 	filename, _ = filepath.Abs(filename)
 
-	fileNode, fileSet, err := ast.ParseFile(filename)
+	file, config, err := loadFile(filename)
 	if err != nil {
-		panic(err)
+		diagnostics.Append(err, token.Position{Filename: filename})
+		return
 	}
 
-	eg, err := enum.NewGenerator(fileNode, fileSet)
+	_, diagnostics = generateFile(filename, file, config, config.Suffix(outputSuffix))
+	return
+}
+
+// loadFile parses the source file filename together with the other files of its package, so the generators can
+// type check it. If the package can not be loaded, the file is type checked alone.
+// It also returns the configuration of the module containing the file.
+func loadFile(filename string) (*ast.File, *Config, error) {
+	config, err := LoadConfig(filepath.Dir(filename))
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
-	if eg != nil {
+	var file *ast.File
+	fileSet := token.NewFileSet()
+	if pkgs, err := LoadPackages(fileSet, filepath.Dir(filename)); err == nil {
+		for _, pkg := range pkgs {
+			for i, name := range pkg.FileNames {
+				if name == filename {
+					file = ast.NewFile(pkg.Files[i], fileSet, pkg.TypeFiles)
+				}
+			}
+		}
+	}
 
-		buf := bytes.NewBuffer([]byte{})
+	if file == nil {
+		fileNode, fileSet, err := ast.ParseFile(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+		file = ast.NewFile(fileNode, fileSet, nil)
+	}
 
-		buf.WriteString("// Code generated by https://github.com/peace0phmind/bud DO NOT EDIT.\n\n\n")
+	file.Defaults = config.Defaults(filepath.Dir(filename))
+	file.MetaAnnotations = config.Metas()
+	return file, config, nil
+}
 
-		// write package
-		buf.WriteString("package " + fileNode.Name.Name)
-		buf.WriteString("\n\n")
+// generateFile writes the bud file of the parsed source file, it returns the path of the written bud file,
+// or an empty string if the source file has nothing to generate or has errors.
+// A bud file left from a source file which has nothing to generate any more is removed.
+func generateFile(filename string, file *ast.File, config *Config, outputSuffix string) (string, ast.Diagnostics) {
+	eg, diagnostics := newGenerators(file, config)
+	if diagnostics.HasErrors() {
+		return "", diagnostics
+	}
 
-		// write import
-		importList := eg.GetImports()
-		buf.WriteString("import (\n")
-		for _, imp := range importList {
-			buf.WriteString("\t\"" + imp + "\"")
-			buf.WriteString("\n")
+	outFilePath := OutputFilePath(filename, outputSuffix)
+	if len(eg) == 0 {
+		// remove the bud file generated before all annotations are removed from the source file
+		if isGeneratedFile(outFilePath) {
+			diagnostics.Append(os.Remove(outFilePath), token.Position{Filename: outFilePath})
 		}
-		buf.WriteString(")\n\n")
+		diagnostics.Append(writeExtraFiles(outFilePath, nil), token.Position{Filename: outFilePath})
+		return "", diagnostics
+	}
 
-		err = eg.WriteConst(buf)
-		if err != nil {
-			panic(err)
+	formatted, err := render(file.Node.Name.Name, eg)
+	if err == nil {
+		err = writeFile(outFilePath, formatted)
+	}
+	if err == nil {
+		var extraFiles map[string][]byte
+		if !strings.HasSuffix(filename, "_test.go") {
+			extraFiles, err = renderExtraFiles(eg)
 		}
+		if err == nil {
+			err = writeExtraFiles(outFilePath, extraFiles)
+		}
+	}
+	if err != nil {
+		diagnostics.Append(err, token.Position{Filename: filename})
+		return "", diagnostics
+	}
 
-		err = eg.WriteInitFunc(buf)
-		if err != nil {
-			panic(err)
+	return outFilePath, diagnostics
+}
+
+// writeExtraFiles writes the extra files of the bud file outFilePath keyed by their extensions, and removes the extra
+// files generated before which are not generated any more.
+func writeExtraFiles(outFilePath string, extraFiles map[string][]byte) error {
+	for ext, content := range extraFiles {
+		if err := writeFile(ExtraFilePath(outFilePath, ext), content); err != nil {
+			return err
 		}
-		buf.WriteString("\n")
+	}
 
-		err = eg.WriteBody(buf)
-		if err != nil {
-			panic(err)
+	base := strings.TrimSuffix(outFilePath, ".go")
+	matches, _ := filepath.Glob(base + ".*")
+	for _, match := range matches {
+		ext := filepath.Ext(match)
+		if ext == ".go" || strings.TrimSuffix(match, ext) != base || extraFiles[ext] != nil || !isGeneratedFile(match) {
+			continue
 		}
+		if err := os.Remove(match); err != nil {
+			return err
+		}
+	}
 
-		formatted, err := imports.Process(fileNode.Name.Name, buf.Bytes(), nil)
-		if err != nil {
-			panic(fmt.Errorf("generate: error formatting code %s\n\n%s", err, buf.String()))
+	return nil
+}
+
+// ExtraFilePath returns the path of the extra file with extension ext written next to the bud file outFilePath,
+// see ast.ExtraFileGenerator.
+func ExtraFilePath(outFilePath string, ext string) string {
+	return strings.TrimSuffix(outFilePath, ".go") + ext
+}
+
+// OutputFilePath returns the path of the bud file generated from the source file filename.
+func OutputFilePath(filename string, outputSuffix string) string {
+	outFilePath := fmt.Sprintf("%s%s.go", strings.TrimSuffix(filename, filepath.Ext(filename)), outputSuffix)
+	if strings.HasSuffix(filename, "_test.go") {
+		outFilePath = strings.Replace(outFilePath, "_test"+outputSuffix+".go", outputSuffix+"_test.go", 1)
+	}
+	return outFilePath
+}
+
+// FileOutputSuffix returns the value of the -file-suffix flag passed to bud in a //go:generate directive of the file,
+// or outputSuffix if the file does not change it.
+func FileOutputSuffix(fileNode *goast.File, outputSuffix string) string {
+	for _, cg := range fileNode.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, "//go:generate ") {
+				continue
+			}
+
+			args := strings.Fields(strings.TrimPrefix(c.Text, "//go:generate "))
+			for i, arg := range args {
+				if !strings.HasPrefix(arg, "-") {
+					continue
+				}
+
+				arg = strings.TrimLeft(arg, "-")
+				if arg == "file-suffix" && i+1 < len(args) {
+					return args[i+1]
+				}
+				if strings.HasPrefix(arg, "file-suffix=") {
+					return strings.TrimPrefix(arg, "file-suffix=")
+				}
+			}
+		}
+	}
+
+	return outputSuffix
+}
+
+// newGenerators runs all generators enabled by config on the file and returns the ones having something to generate.
+// A generator returning only warnings is still used.
+func newGenerators(file *ast.File, config *Config) ([]ast.Generator, ast.Diagnostics) {
+	var result []ast.Generator
+	var diagnostics ast.Diagnostics
+
+	for _, name := range ast.GeneratorNames() {
+		if !config.Enabled(name) {
+			continue
 		}
 
-		outFilePath := fmt.Sprintf("%s%s.go", strings.TrimSuffix(filename, filepath.Ext(filename)), outputSuffix)
-		if strings.HasSuffix(filename, "_test.go") {
-			outFilePath = strings.Replace(outFilePath, "_test"+outputSuffix+".go", outputSuffix+"_test.go", 1)
+		var gd ast.Diagnostics
+		g, err := ast.GetGenerator(name)(file)
+		gd.Append(err, file.FileSet.Position(file.Node.Package))
+		diagnostics.Add(gd...)
+
+		if g != nil && !gd.HasErrors() {
+			result = append(result, g)
 		}
+	}
 
-		mode := int(0o644)
-		err = os.WriteFile(outFilePath, formatted, os.FileMode(mode))
-		if err != nil {
-			panic(fmt.Errorf("failed writing to file %s: %s", outFilePath, err))
+	return result, diagnostics
+}
+
+// render writes the sections of all generators into one formatted go file of package pkgName.
+func render(pkgName string, generators []ast.Generator) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+
+	buf.WriteString(GeneratedHeader + "\n\n\n")
+
+	// write package
+	buf.WriteString("package " + pkgName)
+	buf.WriteString("\n\n")
+
+	// write import
+	importSet := map[string]bool{}
+	buf.WriteString("import (\n")
+	for _, g := range generators {
+		for _, imp := range g.GetImports() {
+			if !importSet[imp] {
+				importSet[imp] = true
+				if name, path, ok := strings.Cut(imp, " "); ok {
+					buf.WriteString("\t" + name + " \"" + path + "\"")
+				} else {
+					buf.WriteString("\t\"" + imp + "\"")
+				}
+				buf.WriteString("\n")
+			}
 		}
 	}
+	buf.WriteString(")\n\n")
+
+	for _, g := range generators {
+		if err := g.WriteConst(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, g := range generators {
+		if err := g.WriteInitFunc(buf); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteString("\n")
+
+	for _, g := range generators {
+		if err := g.WriteBody(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	formatted, err := imports.Process(pkgName, buf.Bytes(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("generate: error formatting code %s\n\n%s", err, buf.String())
+	}
+
+	return formatted, nil
+}
+
+// renderExtraFiles writes the extra files of all generators implementing ast.ExtraFileGenerator, keyed by their
+// extensions. The generators writing a file with the same extension share it.
+func renderExtraFiles(generators []ast.Generator) (map[string][]byte, error) {
+	buffers := map[string]*bytes.Buffer{}
+	for _, g := range generators {
+		eg, ok := g.(ast.ExtraFileGenerator)
+		if !ok {
+			continue
+		}
+
+		for _, ext := range eg.ExtraFileExts() {
+			buf, ok := buffers[ext]
+			if !ok {
+				buf = bytes.NewBufferString(GeneratedHeader + "\n")
+				buffers[ext] = buf
+			}
+
+			if err := eg.WriteExtraFile(buf, ext); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := make(map[string][]byte, len(buffers))
+	for ext, buf := range buffers {
+		result[ext] = buf.Bytes()
+	}
+	return result, nil
+}
+
+func writeFile(outFilePath string, formatted []byte) error {
+	mode := int(0o644)
+	err := os.WriteFile(outFilePath, formatted, os.FileMode(mode))
+	if err != nil {
+		return fmt.Errorf("failed writing to file %s: %s", outFilePath, err)
+	}
+	return nil
 }
@@ -0,0 +1,347 @@
+package bud
+
+import (
+	"errors"
+	"github.com/peace0phmind/bud/bud/ast"
+	goast "go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Package is a directory of go source files sharing one package clause.
+type Package struct {
+	Dir       string
+	Name      string
+	Test      bool
+	FileNames []string
+	Files     []*goast.File
+	// GeneratedFileNames are the files of the package written by bud, they are only parsed into TypeFiles.
+	GeneratedFileNames []string
+	// TypeFiles are the files type checked together with Files: the generated files, and for the test files of a
+	// package also its non-test files.
+	TypeFiles []*goast.File
+	// GeneratedExtraFileNames are the files of other languages written by bud next to the bud files of the package,
+	// see ast.ExtraFileGenerator. They belong to the package of the non-test files.
+	GeneratedExtraFileNames []string
+	// IgnoredFileNames are the go files of the directory excluded by build constraints.
+	IgnoredFileNames []string
+	// BrokenFileNames are the source files of the package which can not be parsed, they are left out of Files.
+	BrokenFileNames []string
+	// Config is the configuration of the module containing the package.
+	Config *Config
+}
+
+// IsTest reports whether the package only contains _test.go files.
+func (p *Package) IsTest() bool {
+	return p.Test
+}
+
+// IsBroken reports whether any source file of the package can not be parsed, so the generated files of the package
+// are not complete.
+func (p *Package) IsBroken() bool {
+	return len(p.BrokenFileNames) > 0
+}
+
+// LoadPackages parses all packages matched by patterns into fileSet.
+// A pattern is a directory, a pattern ending with "/..." matches the directory and all its subdirectories.
+// The test files of a directory are returned as separate packages. The packages of a module whose configuration can
+// not be loaded are not returned, the error of the configuration is returned instead.
+func LoadPackages(fileSet *token.FileSet, patterns ...string) ([]*Package, error) {
+	var dirs []string
+	for _, pattern := range patterns {
+		matched, err := matchDirs(pattern)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, matched...)
+	}
+
+	var result []*Package
+	var errs []error
+	visited := map[string]bool{}
+	configs := map[string]*Config{}
+
+	for _, dir := range dirs {
+		if visited[dir] {
+			continue
+		}
+		visited[dir] = true
+
+		bp, err := build.ImportDir(dir, 0)
+		if err != nil {
+			var noGoError *build.NoGoError
+			if !errors.As(err, &noGoError) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		var typeFiles []*goast.File
+		config, ok := configs[moduleRoot(dir)]
+		if !ok {
+			if config, err = LoadConfig(dir); err != nil {
+				errs = append(errs, err)
+			}
+			configs[moduleRoot(dir)] = config
+		}
+		// the packages of a module with an invalid configuration are left out, nothing is generated or removed in them
+		if config == nil {
+			continue
+		}
+
+		for i, fileNames := range [][]string{bp.GoFiles, bp.TestGoFiles, bp.XTestGoFiles} {
+			if i == 2 {
+				typeFiles = nil
+			}
+			if len(fileNames) == 0 {
+				continue
+			}
+
+			pkg := &Package{Dir: dir, Name: bp.Name, Test: i > 0, Config: config}
+			if i == 2 {
+				pkg.Name += "_test"
+			}
+			for _, name := range bp.IgnoredGoFiles {
+				pkg.IgnoredFileNames = append(pkg.IgnoredFileNames, filepath.Join(dir, name))
+			}
+			if i == 0 {
+				pkg.GeneratedExtraFileNames = generatedExtraFiles(dir)
+			}
+
+			for _, name := range fileNames {
+				fileName := filepath.Join(dir, name)
+				if isGeneratedFile(fileName) {
+					pkg.GeneratedFileNames = append(pkg.GeneratedFileNames, fileName)
+					// a broken generated file is regenerated, so it is only left out of type checking
+					if fileNode, err := parser.ParseFile(fileSet, fileName, nil, 0); err == nil {
+						typeFiles = append(typeFiles, fileNode)
+					}
+					continue
+				}
+
+				fileNode, err := parser.ParseFile(fileSet, fileName, nil, parser.ParseComments)
+				if err != nil {
+					errs = append(errs, err)
+					pkg.BrokenFileNames = append(pkg.BrokenFileNames, fileName)
+					continue
+				}
+				typeFiles = append(typeFiles, fileNode)
+
+				pkg.Name = fileNode.Name.Name
+				pkg.FileNames = append(pkg.FileNames, fileName)
+				pkg.Files = append(pkg.Files, fileNode)
+			}
+
+			pkg.TypeFiles = typeFiles
+			if len(pkg.Files) > 0 || len(pkg.GeneratedFileNames) > 0 || pkg.IsBroken() {
+				result = append(result, pkg)
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func matchDirs(pattern string) ([]string, error) {
+	recursive := isRecursivePattern(pattern)
+	if recursive {
+		pattern = strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/")
+		if len(pattern) == 0 {
+			pattern = "."
+		}
+	}
+
+	root, err := filepath.Abs(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if !recursive {
+		return []string{root}, nil
+	}
+
+	var dirs []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if path != root && skipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+
+		dirs = append(dirs, path)
+		return nil
+	})
+
+	sort.Strings(dirs)
+	return dirs, err
+}
+
+// isRecursivePattern reports whether the pattern matches the directory and all its subdirectories.
+func isRecursivePattern(pattern string) bool {
+	return pattern == "..." || strings.HasSuffix(pattern, "/...")
+}
+
+// skipDir reports whether the directory name is ignored when matching the packages of a "..." pattern, as the go tool does.
+func skipDir(name string) bool {
+	return name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// generatedExtraFiles returns the files of dir which are not go files, but were written by bud.
+func generatedExtraFiles(dir string) []string {
+	entries, _ := os.ReadDir(dir)
+
+	var result []string
+	for _, entry := range entries {
+		fileName := filepath.Join(dir, entry.Name())
+		if entry.Type().IsRegular() && filepath.Ext(fileName) != ".go" && isGeneratedFile(fileName) {
+			result = append(result, fileName)
+		}
+	}
+	return result
+}
+
+// isGeneratedFile reports whether fileName was written by bud.
+func isGeneratedFile(fileName string) bool {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header := make([]byte, len(GeneratedHeader))
+	n, _ := f.Read(header)
+	return string(header[:n]) == GeneratedHeader
+}
+
+// Output is a bud file generated in memory.
+type Output struct {
+	Path    string
+	Content []byte
+}
+
+// GeneratePackages generates bud files for every package matched by patterns in a single run.
+// If perPackage is true, one bud file named after the package is written for each package,
+// otherwise one bud file is written for each annotated source file.
+// The orphaned bud files of a package without errors are removed, see Orphans.
+// The errors and warnings of all packages are collected and returned together.
+func GeneratePackages(patterns []string, outputSuffix string, perPackage bool) (diagnostics ast.Diagnostics) {
+	fileSet := token.NewFileSet()
+
+	pkgs, err := LoadPackages(fileSet, patterns...)
+	diagnostics.Append(err, token.Position{})
+
+	for _, pkg := range pkgs {
+		outputs, pd := GenerateOutputs(pkg, fileSet, outputSuffix, perPackage)
+		diagnostics.Add(pd...)
+
+		for _, output := range outputs {
+			diagnostics.Append(writeFile(output.Path, output.Content), token.Position{Filename: output.Path})
+		}
+
+		if !pd.HasErrors() {
+			for _, orphan := range Orphans(pkg, outputs, outputSuffix) {
+				diagnostics.Append(os.Remove(orphan), token.Position{Filename: orphan})
+			}
+		}
+	}
+
+	return
+}
+
+// GenerateOutputs generates the bud files of pkg in memory, nothing is returned for a file having errors.
+// If perPackage is true, the package only has one output and nothing is returned if any file of the package has errors
+// or the package is broken.
+func GenerateOutputs(pkg *Package, fileSet *token.FileSet, outputSuffix string, perPackage bool) (outputs []*Output, diagnostics ast.Diagnostics) {
+	var pkgGenerators []ast.Generator
+	outputSuffix = pkg.Config.Suffix(outputSuffix)
+	defaults := pkg.Config.Defaults(pkg.Dir)
+
+	for i, fileNode := range pkg.Files {
+		file := ast.NewFile(fileNode, fileSet, pkg.TypeFiles)
+		file.Defaults = defaults
+		file.MetaAnnotations = pkg.Config.Metas()
+		generators, gd := newGenerators(file, pkg.Config)
+		diagnostics.Add(gd...)
+		if gd.HasErrors() || len(generators) == 0 {
+			continue
+		}
+
+		if perPackage {
+			pkgGenerators = append(pkgGenerators, generators...)
+			continue
+		}
+
+		formatted, err := render(pkg.Name, generators)
+		if err != nil {
+			diagnostics.Append(err, token.Position{Filename: pkg.FileNames[i]})
+			continue
+		}
+
+		output := &Output{
+			Path:    OutputFilePath(pkg.FileNames[i], FileOutputSuffix(fileNode, outputSuffix)),
+			Content: formatted,
+		}
+		extraOutputs, err := generateExtraOutputs(pkg, output, generators)
+		if err != nil {
+			diagnostics.Append(err, token.Position{Filename: pkg.FileNames[i]})
+			continue
+		}
+		outputs = append(append(outputs, output), extraOutputs...)
+	}
+
+	if len(pkgGenerators) > 0 && !diagnostics.HasErrors() && !pkg.IsBroken() {
+		formatted, err := render(pkg.Name, pkgGenerators)
+		var extraOutputs []*Output
+		output := &Output{Path: PackageOutputFilePath(pkg, outputSuffix), Content: formatted}
+		if err == nil {
+			extraOutputs, err = generateExtraOutputs(pkg, output, pkgGenerators)
+		}
+		if err != nil {
+			diagnostics.Append(err, token.Position{Filename: pkg.Dir})
+		} else {
+			outputs = append(append(outputs, output), extraOutputs...)
+		}
+	}
+
+	return
+}
+
+// generateExtraOutputs generates the extra files written next to the bud file output, see ast.ExtraFileGenerator.
+// The test files of a package have no extra files.
+func generateExtraOutputs(pkg *Package, output *Output, generators []ast.Generator) ([]*Output, error) {
+	if pkg.IsTest() {
+		return nil, nil
+	}
+
+	extraFiles, err := renderExtraFiles(generators)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Output
+	for ext, content := range extraFiles {
+		result = append(result, &Output{Path: ExtraFilePath(output.Path, ext), Content: content})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// PackageOutputFilePath returns the path of the bud file generated for the whole package.
+func PackageOutputFilePath(pkg *Package, outputSuffix string) string {
+	if pkg.IsTest() {
+		return filepath.Join(pkg.Dir, pkg.Name+outputSuffix+"_test.go")
+	}
+	return filepath.Join(pkg.Dir, pkg.Name+outputSuffix+".go")
+}
@@ -0,0 +1,29 @@
+package bud
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go/token"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPackages(t *testing.T) {
+	fileSet := token.NewFileSet()
+
+	pkgs, err := LoadPackages(fileSet, "./example/enum/...")
+	assert.NoError(t, err)
+
+	dir, _ := filepath.Abs("./example/enum")
+
+	var names []string
+	for _, pkg := range pkgs {
+		assert.Equal(t, dir, pkg.Dir)
+		assert.Equal(t, len(pkg.FileNames), len(pkg.Files))
+		for _, fileName := range pkg.FileNames {
+			assert.False(t, isGeneratedFile(fileName), fileName)
+		}
+		names = append(names, PackageOutputFilePath(pkg, "_bud"))
+	}
+
+	assert.Equal(t, []string{filepath.Join(dir, "enum_bud.go"), filepath.Join(dir, "enum_bud_test.go")}, names)
+}
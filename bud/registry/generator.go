@@ -0,0 +1,135 @@
+package registry
+
+import (
+	"embed"
+	"github.com/peace0phmind/bud/bud/ast"
+	goast "go/ast"
+	"strings"
+	"text/template"
+)
+
+//go:embed registry.tmpl
+var registryTmpl embed.FS
+
+var tmpl = template.Must(template.New("registry").ParseFS(registryTmpl, "*.tmpl"))
+
+func init() {
+	ast.RegisterGenerator("registry", NewGenerator)
+	ast.RegisterSchema(ast.NewSchema[Config]("Registry"))
+}
+
+type RegistryGenerator struct {
+	ast.BaseGenerator[Registry]
+}
+
+func (rg *RegistryGenerator) GetImports() []string {
+	return []string{"github.com/peace0phmind/bud/annotations"}
+}
+
+// NewGenerator generates an init function registering the annotations of the types marked with @Registry into the
+// runtime registry of the annotations package, so they can be read with annotations.Of[T]().
+func NewGenerator(file *ast.File) (ast.Generator, error) {
+	var diagnostics ast.Diagnostics
+	fileNode, fileSet := file.Node, file.FileSet
+
+	// the @Registry of the project or of the file registers all annotated types of the file
+	var fileConfig *Config
+	if a := file.Defaults.FindAnnotationByName("Registry"); a != nil {
+		c, err := ast.AnnotationParamsTo[Config](nil, a)
+		if err != nil {
+			diagnostics.Append(err, fileSet.Position(fileNode.Package))
+			return nil, diagnostics
+		}
+		fileConfig = c
+	}
+
+	for _, cg := range fileNode.Comments {
+		if strings.HasPrefix(cg.List[len(cg.List)-1].Text, "//go:generate") {
+			if !strings.Contains(strings.ToLower(cg.Text()), "@registry") {
+				break
+			}
+
+			ag, err := file.ParseCommentGroup(cg)
+			if err != nil {
+				diagnostics.Append(err, fileSet.Position(cg.Pos()))
+				if diagnostics.HasErrors() {
+					return nil, diagnostics
+				}
+				break
+			}
+
+			if a := ag.FindAnnotationByName("Registry"); a != nil {
+				c, err := ast.AnnotationParamsTo[Config](nil, a)
+				if err != nil {
+					diagnostics.Append(err, fileSet.Position(cg.Pos()))
+					return nil, diagnostics
+				}
+				fileConfig = c
+			}
+			break
+		}
+	}
+
+	types := ast.InspectMapper[goast.TypeSpec, Type](fileNode, fileSet, func(ts *goast.TypeSpec) *Type {
+		cg := ts.Doc
+		if cg == nil {
+			cg = ts.Comment
+		}
+		if cg == nil || !strings.Contains(cg.Text(), "@") {
+			return nil
+		}
+
+		explicit := strings.Contains(strings.ToLower(cg.Text()), "@registry")
+		if !explicit && fileConfig == nil {
+			return nil
+		}
+
+		ag, err := file.ParseCommentGroup(cg)
+		if err != nil {
+			// a comment of a type not marked with @Registry may hold an "@" without being an annotation
+			if explicit {
+				diagnostics.Append(err, fileSet.Position(cg.Pos()))
+			}
+			return nil
+		}
+
+		config := fileConfig
+		if a := ag.FindAnnotationByName("Registry"); a != nil {
+			if config, err = ast.AnnotationParamsTo[Config](nil, a); err != nil {
+				diagnostics.Append(err, fileSet.Position(ts.Pos()))
+				return nil
+			}
+		}
+		if config == nil {
+			return nil
+		}
+
+		if ts.TypeParams != nil {
+			if explicit {
+				diagnostics.Add(ast.Warningf(fileSet.Position(ts.Pos()), "@Registry of generic type %s is ignored", ts.Name.Name))
+			}
+			return nil
+		}
+
+		t := &Type{Name: ts.Name.Name}
+		for _, a := range ag.Annotations {
+			if config.registers(a.Name.Text) {
+				t.Annotations = append(t.Annotations, annotationLiteral(a))
+			}
+		}
+		if len(t.Annotations) == 0 {
+			return nil
+		}
+
+		return t
+	})
+
+	if diagnostics.HasErrors() || len(types) == 0 {
+		return nil, diagnostics.Err()
+	}
+
+	result := &RegistryGenerator{}
+	result.Tmpl = tmpl
+	result.DataList = []*Registry{{Types: types}}
+	return result, diagnostics.Err()
+}
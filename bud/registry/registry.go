@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"fmt"
+	"github.com/peace0phmind/bud/bud/ast"
+	"strconv"
+	"strings"
+)
+
+// Config is the @Registry annotation, it registers the annotations of the type it is given on, or of all annotated
+// types of the file if it is given in the comment group of //go:generate or in the package defaults of bud.yaml.
+type Config struct {
+	// Only are the names of the registered annotations, all annotations are registered by default.
+	Only []string `annotation:"only"`
+}
+
+// registers reports whether the annotation name is registered.
+func (c *Config) registers(name string) bool {
+	if strings.EqualFold(name, "Registry") {
+		return false
+	}
+	if len(c.Only) == 0 {
+		return true
+	}
+
+	for _, only := range c.Only {
+		if strings.EqualFold(only, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Type is an annotated type, its annotations are rendered as go expressions of *annotations.Annotation.
+type Type struct {
+	Name        string
+	Annotations []string
+}
+
+// Registry is the data of the registry template for a file.
+type Registry struct {
+	Types []*Type
+}
+
+// annotationLiteral renders a as a go expression of *annotations.Annotation.
+func annotationLiteral(a *ast.Annotation) string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "&annotations.Annotation{Name: %q", a.Name.Text)
+
+	if a.Params != nil && len(a.Params.List) > 0 {
+		sb.WriteString(", Params: []annotations.Param{")
+		for i, p := range a.Params.List {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(sb, "{Key: %q", p.Key.Text)
+			if p.Value != nil {
+				sb.WriteString(", Value: " + valueLiteral(p.Value))
+			}
+			sb.WriteString("}")
+		}
+		sb.WriteString("}")
+	}
+
+	if a.Extends != nil && len(a.Extends.List) > 0 {
+		sb.WriteString(", Extends: []annotations.Extend{")
+		for i, ex := range a.Extends.List {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(sb, "{Name: %q", ex.Name.Text)
+			if len(ex.Values) > 0 {
+				sb.WriteString(", Values: " + listLiteral(ex.Values))
+			}
+			if ex.Value != nil {
+				sb.WriteString(", Value: " + valueLiteral(ex.Value))
+			}
+			sb.WriteString("}")
+		}
+		sb.WriteString("}")
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// valueLiteral renders the annotation value v as a go expression of the value of an annotations.Param.
+func valueLiteral(v ast.Value) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case ast.Bool:
+		return strconv.FormatBool(bool(v.V))
+	case ast.Int:
+		return strconv.Itoa(v.V)
+	case ast.Uint:
+		return fmt.Sprintf("uint(%d)", v.V)
+	case ast.Float:
+		s := strconv.FormatFloat(v.V, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eIN") {
+			s += ".0"
+		}
+		return s
+	case ast.String:
+		return strconv.Quote(v.V)
+	case ast.List:
+		return listLiteral(v.V)
+	case ast.Map:
+		// a key given twice is a compile error in a map literal, the later value wins
+		var keys []string
+		values := map[string]string{}
+		for _, e := range v.Entries {
+			if _, ok := values[e.Key]; !ok {
+				keys = append(keys, e.Key)
+			}
+			values[e.Key] = valueLiteral(e.Value)
+		}
+
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			entries[i] = strconv.Quote(key) + ": " + values[key]
+		}
+		return "map[string]any{" + strings.Join(entries, ", ") + "}"
+	case ast.NestedAnnotation:
+		return annotationLiteral(v.Annotation())
+	default:
+		return fmt.Sprintf("%#v", v.Value())
+	}
+}
+
+func listLiteral(values []ast.Value) string {
+	items := make([]string, len(values))
+	for i, item := range values {
+		items[i] = valueLiteral(item)
+	}
+	return "[]any{" + strings.Join(items, ", ") + "}"
+}
@@ -1,11 +1,13 @@
-package enum
+package singleton
 
 import (
 	"github.com/peace0phmind/bud/bud/ast"
-	goast "go/ast"
-	"go/token"
 )
 
+func init() {
+	ast.RegisterGenerator("singleton", Generate)
+}
+
 type SingletonGenerator struct {
 	ast.BaseGenerator[Singleton]
 }
@@ -20,6 +22,6 @@ func (sg *SingletonGenerator) GetImports() []string {
 	return []string{"fmt"}
 }
 
-func Generate(fileNode *goast.File, fileSet *token.FileSet) (ast.Generator, error) {
+func Generate(file *ast.File) (ast.Generator, error) {
 	return nil, nil
 }
@@ -1,4 +1,4 @@
-package enum
+package singleton
 
 type Singleton struct {
 }
@@ -1 +1 @@
-package enum
+package singleton
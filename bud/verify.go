@@ -0,0 +1,73 @@
+package bud
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/peace0phmind/bud/bud/ast"
+	"github.com/pmezard/go-difflib/difflib"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// VerifyPackages regenerates the bud files of every package matched by patterns in memory and compares them
+// with the files on disk, nothing is written to disk.
+// The unified diff of every stale bud file is written to w, and an error is reported for it and every orphaned bud file.
+func VerifyPackages(patterns []string, outputSuffix string, perPackage bool, w io.Writer) (diagnostics ast.Diagnostics) {
+	fileSet := token.NewFileSet()
+
+	pkgs, err := LoadPackages(fileSet, patterns...)
+	diagnostics.Append(err, token.Position{})
+
+	for _, pkg := range pkgs {
+		outputs, pd := GenerateOutputs(pkg, fileSet, outputSuffix, perPackage)
+		diagnostics.Add(pd...)
+
+		for _, output := range outputs {
+			diagnostics.Append(verifyOutput(output, w), token.Position{Filename: output.Path})
+		}
+
+		if !pd.HasErrors() {
+			for _, orphan := range Orphans(pkg, outputs, outputSuffix) {
+				diagnostics.Add(ast.Errorf(token.Position{Filename: orphan}, "orphaned bud file, its source no longer generates it, run bud clean"))
+			}
+		}
+	}
+
+	return
+}
+
+// verifyOutput compares the output with the file on disk, and writes the unified diff to w if they differ.
+func verifyOutput(output *Output, w io.Writer) error {
+	onDisk, err := os.ReadFile(output.Path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if bytes.Equal(onDisk, output.Content) {
+		return nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(onDisk)),
+		B:        difflib.SplitLines(string(output.Content)),
+		FromFile: output.Path,
+		ToFile:   output.Path + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.WriteString(w, diff); err != nil {
+		return err
+	}
+
+	if onDisk == nil {
+		return fmt.Errorf("bud file is missing, run go generate")
+	}
+
+	return fmt.Errorf("bud file is out of date, run go generate")
+}
@@ -0,0 +1,33 @@
+package bud
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "color_bud.go")
+	output := &Output{Path: path, Content: []byte("package enum\n\nconst a = 1\n")}
+
+	w := &bytes.Buffer{}
+	assert.EqualError(t, verifyOutput(output, w), "bud file is missing, run go generate")
+	assert.Contains(t, w.String(), "+const a = 1\n")
+
+	assert.NoError(t, os.WriteFile(path, []byte("package enum\n\nconst a = 2\n"), 0o644))
+	w.Reset()
+	assert.EqualError(t, verifyOutput(output, w), "bud file is out of date, run go generate")
+	assert.Contains(t, w.String(), "-const a = 2\n+const a = 1\n")
+
+	assert.NoError(t, os.WriteFile(path, output.Content, 0o644))
+	w.Reset()
+	assert.NoError(t, verifyOutput(output, w))
+	assert.Empty(t, w.String())
+}
+
+func TestVerifyPackages(t *testing.T) {
+	diagnostics := VerifyPackages([]string{"./example/..."}, "_bud", false, &bytes.Buffer{})
+	assert.False(t, diagnostics.HasErrors(), diagnostics.Error())
+}
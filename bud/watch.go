@@ -0,0 +1,131 @@
+package bud
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/peace0phmind/bud/bud/ast"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Watch watches the go files in the directories matched by patterns, and regenerates the bud file of a source file
+// when it is saved. Changes of a file are debounced by delay, so a burst of writes only regenerates once.
+// The result of every regeneration is written to w, Watch blocks until ctx is done.
+// ready, if not nil, is called once all the matched directories are watched.
+func Watch(ctx context.Context, patterns []string, outputSuffix string, delay time.Duration, w io.Writer, ready func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// recursive holds the watched directories whose new subdirectories are watched too
+	recursive := map[string]bool{}
+	for _, pattern := range patterns {
+		dirs, err := matchDirs(pattern)
+		if err != nil {
+			return err
+		}
+
+		for _, dir := range dirs {
+			if err = watcher.Add(dir); err != nil {
+				return fmt.Errorf("watch %s: %w", dir, err)
+			}
+			recursive[dir] = recursive[dir] || isRecursivePattern(pattern)
+		}
+	}
+
+	if ready != nil {
+		ready()
+	}
+
+	timers := map[string]*time.Timer{}
+	changed := make(chan string)
+	defer func() {
+		for _, t := range timers {
+			t.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			_, _ = fmt.Fprintf(w, "watch error: %v\n", err)
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				if fi, err := os.Stat(event.Name); err == nil && fi.IsDir() {
+					if recursive[filepath.Dir(event.Name)] && !skipDir(fi.Name()) {
+						if err = watcher.Add(event.Name); err == nil {
+							recursive[event.Name] = true
+						}
+					}
+					continue
+				}
+			}
+
+			if !strings.HasSuffix(event.Name, ".go") {
+				continue
+			}
+
+			filename := event.Name
+			if t, ok := timers[filename]; ok {
+				t.Reset(delay)
+			} else {
+				timers[filename] = time.AfterFunc(delay, func() {
+					select {
+					case changed <- filename:
+					case <-ctx.Done():
+					}
+				})
+			}
+
+		case filename := <-changed:
+			delete(timers, filename)
+			regenerate(filename, outputSuffix, w)
+		}
+	}
+}
+
+// regenerate generates the bud file of a changed source file, and writes the result to w.
+func regenerate(filename string, outputSuffix string, w io.Writer) {
+	if _, err := os.Stat(filename); err != nil || isGeneratedFile(filename) {
+		return
+	}
+
+	filename, _ = filepath.Abs(filename)
+
+	var diagnostics ast.Diagnostics
+	file, config, err := loadFile(filename)
+	if err != nil {
+		diagnostics.Append(err, token.Position{Filename: filename})
+	} else {
+		var outFilePath string
+		outFilePath, diagnostics = generateFile(filename, file, config, FileOutputSuffix(file.Node, config.Suffix(outputSuffix)))
+		if len(outFilePath) > 0 {
+			_, _ = fmt.Fprintf(w, "%s: generated %s\n", filename, filepath.Base(outFilePath))
+		}
+	}
+
+	diagnostics.Sort()
+	diagnostics.Print(w)
+}
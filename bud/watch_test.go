@@ -0,0 +1,93 @@
+package bud
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+	return sb.buf.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+	return sb.buf.String()
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &syncBuffer{}
+	ready := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, []string{dir}, "_bud", 10*time.Millisecond, w, func() { close(ready) })
+	}()
+	<-ready
+
+	source := filepath.Join(dir, "color.go")
+	assert.NoError(t, os.WriteFile(source, []byte("package color\n\n// @ENUM{red, green}\ntype Color int\n"), 0o644))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(dir, "color_bud.go"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, os.WriteFile(source, []byte("package color\n\n// @ENUM{red, red}\ntype Color int\n"), 0o644))
+
+	assert.Eventually(t, func() bool {
+		return bytes.Contains([]byte(w.String()), []byte("color.go:3:15: error: enum item names must be unique, red"))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestWatchNewDir(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ready := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, []string{dir + "/..."}, "_bud", 10*time.Millisecond, &syncBuffer{}, func() { close(ready) })
+	}()
+	<-ready
+
+	source := []byte("package color\n\n// @ENUM{red, green}\ntype Color int\n")
+	for _, sub := range []string{"_skip", "testdata", "color"} {
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, sub), 0o755))
+	}
+
+	// the new directory may be watched after the file is written, so write it until it is generated
+	assert.Eventually(t, func() bool {
+		_ = os.WriteFile(filepath.Join(dir, "_skip", "color.go"), source, 0o644)
+		_ = os.WriteFile(filepath.Join(dir, "testdata", "color.go"), source, 0o644)
+		_ = os.WriteFile(filepath.Join(dir, "color", "color.go"), source, 0o644)
+		_, err := os.Stat(filepath.Join(dir, "color", "color_bud.go"))
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	assert.NoFileExists(t, filepath.Join(dir, "_skip", "color_bud.go"))
+	assert.NoFileExists(t, filepath.Join(dir, "testdata", "color_bud.go"))
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,67 @@
+// Package jsonschema is the runtime registry of the JSON schemas of types, filled by the init functions generated by
+// bud for the enums with @EnumConfig(jsonSchema). The schemas of a package are the components of an OpenAPI document.
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[reflect.Type]json.RawMessage{}
+)
+
+// Register registers the JSON schema of T, it is called by the generated init functions.
+func Register[T any](schema json.RawMessage) {
+	RegisterType(reflect.TypeOf((*T)(nil)).Elem(), schema)
+}
+
+// RegisterType registers the JSON schema of t, registering a type twice replaces its schema.
+func RegisterType(t reflect.Type, schema json.RawMessage) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registry[t] = schema
+}
+
+// Of returns the JSON schema of T, or nil if none is registered.
+func Of[T any]() json.RawMessage {
+	return OfType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// OfType returns the JSON schema of t, or nil if none is registered.
+func OfType(t reflect.Type) json.RawMessage {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return registry[t]
+}
+
+// Package returns the JSON schemas of the types of the package pkgPath keyed by the type names, like the schemas of
+// the components of an OpenAPI document.
+func Package(pkgPath string) map[string]json.RawMessage {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	result := map[string]json.RawMessage{}
+	for t, schema := range registry {
+		if t.PkgPath() == pkgPath {
+			result[t.Name()] = schema
+		}
+	}
+	return result
+}
+
+// All returns the JSON schemas of all registered types keyed by the type strings like "enum.Color".
+func All() map[string]json.RawMessage {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	result := make(map[string]json.RawMessage, len(registry))
+	for t, schema := range registry {
+		result[t.String()] = schema
+	}
+	return result
+}
@@ -0,0 +1,29 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type status int
+
+type level string
+
+func TestRegister(t *testing.T) {
+	Register[status](json.RawMessage(`{"type":"integer"}`))
+	Register[level](json.RawMessage(`{"type":"string"}`))
+
+	assert.JSONEq(t, `{"type":"integer"}`, string(Of[status]()))
+	assert.Nil(t, Of[int]())
+
+	schemas := Package("github.com/peace0phmind/bud/jsonschema")
+	assert.Len(t, schemas, 2)
+	assert.JSONEq(t, `{"type":"string"}`, string(schemas["level"]))
+	assert.Empty(t, Package("github.com/peace0phmind/bud/annotations"))
+
+	assert.Contains(t, All(), "jsonschema.status")
+
+	Register[status](json.RawMessage(`{"type":"string"}`))
+	assert.JSONEq(t, `{"type":"string"}`, string(Of[status]()))
+}
@@ -1,30 +1,193 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"github.com/peace0phmind/bud/bud"
+	"github.com/peace0phmind/bud/bud/ast"
+	"github.com/peace0phmind/bud/bud/enum"
+	"go/token"
 	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
 )
 
+type command struct {
+	usage string
+	run   func(fs *flag.FlagSet, args []string) ast.Diagnostics
+}
+
+var commands = map[string]*command{
+	"annotations": {
+		usage: "annotations [packages]: print the annotations of the packages as JSON",
+		run:   annotations,
+	},
+	"clean": {
+		usage: "clean [flags] [packages]: remove the bud files whose source no longer generates them",
+		run:   clean,
+	},
+	"fmt": {
+		usage: "fmt [flags] [packages]: rewrite the annotations in comments in a canonical layout and list the changed files",
+		run:   format,
+	},
+	"verify": {
+		usage: "verify [flags] [packages]: regenerate the bud files in memory and report the ones differing from disk",
+		run:   verify,
+	},
+	"watch": {
+		usage: "watch [flags] [packages]: regenerate the bud file of a source file whenever it is saved",
+		run:   watch,
+	},
+}
+
 func main() {
+	name, args := "", os.Args[1:]
+	if len(args) > 0 && commands[args[0]] != nil {
+		name, args = args[0], args[1:]
+	}
+
+	var diagnostics ast.Diagnostics
+	if len(name) == 0 {
+		diagnostics = generate(flag.CommandLine, args)
+	} else {
+		diagnostics = commands[name].run(flag.NewFlagSet(name, flag.ExitOnError), args)
+	}
+
+	diagnostics.Sort()
+	diagnostics.Print(os.Stderr)
+	if diagnostics.HasErrors() {
+		os.Exit(1)
+	}
+}
+
+// enumTemplateVar defines the -enum-template flag, the templates are set to the enum generator by Parse.
+type enumTemplateVar struct{}
+
+func (enumTemplateVar) String() string { return "" }
+
+func (enumTemplateVar) Set(value string) error {
+	enum.SetTemplateFiles(strings.Split(value, ",")...)
+	return nil
+}
+
+func addEnumTemplateFlag(fs *flag.FlagSet) {
+	fs.Var(enumTemplateVar{}, "enum-template", "Comma separated template files overriding or extending the enum template, glob patterns are allowed.")
+}
+
+// packagePatterns returns the package patterns of the arguments, the current directory tree by default.
+func packagePatterns(args []string) []string {
+	if len(args) == 0 {
+		return []string{"./..."}
+	}
+	return args
+}
+
+func generate(fs *flag.FlagSet, args []string) ast.Diagnostics {
 	var filename string
 	var fileSuffix string
+	var pkg string
+	var perPackage bool
 
-	flag.StringVar(&filename, "file", "", "The file to generate bud file.")
-	flag.StringVar(&fileSuffix, "file-suffix", "_bud", "Changes the default filename suffix of _bud to something else.")
+	fs.StringVar(&filename, "file", "", "The file to generate bud file.")
+	fs.StringVar(&fileSuffix, "file-suffix", "", "Changes the default filename suffix of _bud, or the outputSuffix of bud.yaml, to something else.")
+	fs.StringVar(&pkg, "pkg", "", "Comma separated package directories to generate bud files for, a trailing /... includes all subdirectories.")
+	fs.BoolVar(&perPackage, "per-package", false, "Write one bud file per package instead of one per source file, only used with -pkg.")
+	addEnumTemplateFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s:\n", os.Args[0])
+		fs.PrintDefaults()
+		fmt.Fprintf(fs.Output(), "\nCommands:\n")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(fs.Output(), "  %s\n", commands[name].usage)
+		}
+	}
 
-	flag.Parse()
+	_ = fs.Parse(args)
+
+	if len(pkg) > 0 {
+		patterns := append(strings.Split(pkg, ","), fs.Args()...)
+		return bud.GeneratePackages(patterns, fileSuffix, perPackage)
+	}
 
 	if len(filename) == 0 {
 		filename, _ = os.LookupEnv("GOFILE")
 
 		if len(filename) == 0 {
-			fmt.Fprintf(os.Stdout, "Usage of %s:\n", os.Args[0])
-			flag.PrintDefaults()
-			return
+			fs.SetOutput(os.Stdout)
+			fs.Usage()
+			return nil
 		}
 	}
 
-	bud.GenerateFile(filename, fileSuffix)
+	return bud.GenerateFile(filename, fileSuffix)
+}
+
+func verify(fs *flag.FlagSet, args []string) ast.Diagnostics {
+	var fileSuffix string
+	var perPackage bool
+
+	fs.StringVar(&fileSuffix, "file-suffix", "", "The default filename suffix of the bud files, _bud or the outputSuffix of bud.yaml if not set.")
+	fs.BoolVar(&perPackage, "per-package", false, "Verify one bud file per package instead of one per source file.")
+	addEnumTemplateFlag(fs)
+
+	_ = fs.Parse(args)
+
+	return bud.VerifyPackages(packagePatterns(fs.Args()), fileSuffix, perPackage, os.Stdout)
+}
+
+func watch(fs *flag.FlagSet, args []string) (diagnostics ast.Diagnostics) {
+	var fileSuffix string
+	var delay time.Duration
+
+	fs.StringVar(&fileSuffix, "file-suffix", "", "The default filename suffix of the bud files, _bud or the outputSuffix of bud.yaml if not set.")
+	fs.DurationVar(&delay, "delay", 200*time.Millisecond, "Wait for the source file to stop changing before regenerating.")
+	addEnumTemplateFlag(fs)
+
+	_ = fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := bud.Watch(ctx, packagePatterns(fs.Args()), fileSuffix, delay, os.Stderr, nil)
+	diagnostics.Append(err, token.Position{})
+	return
+}
+
+func clean(fs *flag.FlagSet, args []string) ast.Diagnostics {
+	var fileSuffix string
+	var perPackage bool
+	var dryRun bool
+
+	fs.StringVar(&fileSuffix, "file-suffix", "", "The default filename suffix of the bud files, _bud or the outputSuffix of bud.yaml if not set.")
+	fs.BoolVar(&perPackage, "per-package", false, "The bud files are generated one per package instead of one per source file.")
+	fs.BoolVar(&dryRun, "n", false, "Only print the orphaned bud files without removing them.")
+
+	_ = fs.Parse(args)
+
+	return bud.CleanPackages(packagePatterns(fs.Args()), fileSuffix, perPackage, dryRun, os.Stdout)
+}
+
+func annotations(fs *flag.FlagSet, args []string) ast.Diagnostics {
+	_ = fs.Parse(args)
+
+	return bud.DumpAnnotations(packagePatterns(fs.Args()), os.Stdout)
+}
+
+func format(fs *flag.FlagSet, args []string) ast.Diagnostics {
+	var list bool
+
+	fs.BoolVar(&list, "l", false, "Only list the files whose annotations are not formatted, do not rewrite them.")
+
+	_ = fs.Parse(args)
+
+	return bud.FormatPackages(packagePatterns(fs.Args()), !list, os.Stdout)
 }